@@ -0,0 +1,114 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/batch"
+
+	"github.com/gin-gonic/gin"
+)
+
+// batchManager tracks in-flight batch analysis jobs. Set up in main().
+var batchManager *batch.Manager
+
+// BatchFileRequest is one file in a JSON-list POST /api/v1/analyze/batch
+// body: {"files": [{"path": "...", "code": "..."}]}.
+type BatchFileRequest struct {
+	Path string `json:"path" binding:"required"`
+	Code string `json:"code" binding:"required"`
+}
+
+// BatchRequest is the JSON-list form of a batch request; an "archive"
+// multipart file field is accepted as the alternative, zip-upload form.
+type BatchRequest struct {
+	Files []BatchFileRequest `json:"files" binding:"required"`
+}
+
+// analyzeBatch handles POST /api/v1/analyze/batch: it accepts either a
+// multipart "archive" zip upload or a JSON {"files": [...]} body, analyzes
+// every file concurrently through a bounded worker pool, and starts a Job
+// whose progress and final aggregate Report are polled from
+// GET /api/v1/analyze/batch/:jobID/status.
+func analyzeBatch(c *gin.Context) {
+	var files []batch.File
+
+	if header, err := c.FormFile("archive"); err == nil {
+		files, err = filesFromZip(header)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid archive: " + err.Error()})
+			return
+		}
+	} else {
+		var request BatchRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+		for _, f := range request.Files {
+			files = append(files, batch.File{Path: f.Path, Code: []byte(f.Code)})
+		}
+	}
+
+	if len(files) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No files to analyze"})
+		return
+	}
+
+	// The job outlives this request (it's polled via batchStatus), so it
+	// must not inherit the request's context: c.Request.Context() is
+	// cancelled once this handler returns.
+	jobID := batchManager.Start(context.Background(), files, 0)
+	c.JSON(http.StatusAccepted, gin.H{"job_id": jobID})
+}
+
+// batchStatus handles GET /api/v1/analyze/batch/:jobID/status.
+func batchStatus(c *gin.Context) {
+	view, ok := batchManager.Snapshot(c.Param("jobID"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown job ID"})
+		return
+	}
+	c.JSON(http.StatusOK, view)
+}
+
+// filesFromZip reads every regular file out of an uploaded zip archive.
+func filesFromZip(header *multipart.FileHeader) ([]batch.File, error) {
+	f, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []batch.File
+	for _, zf := range r.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, batch.File{Path: zf.Name, Code: content})
+	}
+	return files, nil
+}
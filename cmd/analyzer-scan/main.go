@@ -0,0 +1,85 @@
+// Command analyzer-scan walks an entire repository concurrently and prints
+// an aggregated report: every file's analysis plus a repo-level summary of
+// total complexity, average performance score, and critical-hint hotspots.
+// Unlike cmd/analyzer, it never loads a single whole Go package for AST/
+// taint checks - it just fans AnalyzeCode-equivalent per-file analysis out
+// across a worker pool, which is what makes it practical against a large
+// monorepo.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/crawler"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/langs"
+	"github.com/cheggaaa/pb/v3"
+)
+
+func main() {
+	path := flag.String("path", ".", "Path to scan")
+	workers := flag.Int("workers", 0, "Worker pool size (default: GOMAXPROCS)")
+	noProgress := flag.Bool("no-progress", false, "Disable the progress bar")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	isEligible := func(path string) bool {
+		if strings.Contains(path, "vendor/") {
+			return false
+		}
+		_, ok := langs.ForPath(path)
+		return ok
+	}
+
+	var bar *pb.ProgressBar
+	if !*noProgress && isTerminal(os.Stdout) {
+		paths, err := crawler.Paths(*path, isEligible)
+		if err == nil {
+			bar = pb.New(len(paths))
+			bar.SetTemplateString(`{{ counters . }} {{ bar . }} {{ speed . "%s files/s" }} {{ etime . }}`)
+			bar.Start()
+			defer bar.Finish()
+		}
+	}
+
+	result, err := crawler.Crawl(ctx, *path, func(path string, content []byte) (analysis.CodeAnalysis, error) {
+		lang, _ := langs.ForPath(path)
+		return lang.Analyze(ctx, path, content)
+	}, crawler.Options{
+		Workers:    *workers,
+		IsEligible: isEligible,
+		OnFile: func(path string) {
+			if bar != nil {
+				bar.Increment()
+			}
+		},
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ scan failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	jsonData, _ := json.MarshalIndent(result, "", "  ")
+	fmt.Println(string(jsonData))
+
+	if ctx.Err() != nil {
+		fmt.Fprintf(os.Stderr, "\n⚠️  aborted - showing partial results for %d file(s)\n", len(result.Files))
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
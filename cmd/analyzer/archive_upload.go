@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/archive"
+)
+
+// uploadResults archives every result to the object store described by cfg,
+// under the GITHUB_REPOSITORY (or "local") repo name and the current git
+// SHA. Failure to archive is reported but non-fatal, matching persistResults.
+func uploadResults(ctx context.Context, cfg archive.Config, results []analysis.FileAnalysis) {
+	a, err := archive.New(ctx, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  results not archived: %v\n", err)
+		return
+	}
+
+	repo := os.Getenv("GITHUB_REPOSITORY")
+	if repo == "" {
+		repo = "local"
+	}
+	sha := gitSHA()
+
+	for _, result := range results {
+		if err := a.Upload(ctx, repo, sha, result); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  failed to archive %s: %v\n", result.FilePath, err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	"gopkg.in/yaml.v3"
+)
+
+// baselineFile is the on-disk format written by --write-baseline and read
+// back by --baseline. Fingerprints use the same sha256(ruleId|file|snippet)
+// scheme as the SARIF partialFingerprints, so a finding that's still
+// present (even if its line number shifted) keeps matching the baseline.
+type baselineFile struct {
+	Fingerprints []string `yaml:"fingerprints"`
+}
+
+// writeBaseline serializes every finding currently in results to path.
+func writeBaseline(path string, results []analysis.FileAnalysis) error {
+	var bf baselineFile
+	for _, result := range results {
+		for _, hint := range result.Analysis.PerformanceHints {
+			bf.Fingerprints = append(bf.Fingerprints, fingerprintHash(ruleIDForHint(hint), result.FilePath, hint.Impact))
+		}
+		for _, issue := range result.Analysis.SecurityIssues {
+			bf.Fingerprints = append(bf.Fingerprints, fingerprintHash(ruleIDForIssue(issue), result.FilePath, issue.Description))
+		}
+	}
+
+	data, err := yaml.Marshal(bf)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// loadBaseline reads path and returns its fingerprints as a set.
+func loadBaseline(path string) (map[string]bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var bf baselineFile
+	if err := yaml.Unmarshal(data, &bf); err != nil {
+		return nil, fmt.Errorf("parse baseline %s: %w", path, err)
+	}
+
+	known := make(map[string]bool, len(bf.Fingerprints))
+	for _, fp := range bf.Fingerprints {
+		known[fp] = true
+	}
+	return known, nil
+}
+
+// applyBaseline marks every finding whose fingerprint is already in
+// baseline as Suppressed, so it stops affecting fail-the-build decisions
+// while still being rendered (in a collapsed section, for Markdown).
+func applyBaseline(results []analysis.FileAnalysis, baseline map[string]bool) {
+	for i := range results {
+		hints := results[i].Analysis.PerformanceHints
+		for j := range hints {
+			fp := fingerprintHash(ruleIDForHint(hints[j]), results[i].FilePath, hints[j].Impact)
+			if baseline[fp] {
+				hints[j].Suppressed = true
+			}
+		}
+
+		issues := results[i].Analysis.SecurityIssues
+		for j := range issues {
+			fp := fingerprintHash(ruleIDForIssue(issues[j]), results[i].FilePath, issues[j].Description)
+			if baseline[fp] {
+				issues[j].Suppressed = true
+			}
+		}
+	}
+}
@@ -1,23 +1,64 @@
 package main
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
 	"flag"
 	"fmt"
 	"io/fs"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/archive"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/astengine"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/dbexplain"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/langs"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/metrics"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/store"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/taint"
+	"github.com/aldookware/api-performance-analyzer/internal/cache"
+	"github.com/cheggaaa/pb/v3"
+	"golang.org/x/tools/go/packages"
 )
 
+// analyzerVersion is mixed into cache keys and the SARIF tool.driver block;
+// bump it whenever a change to detection logic should invalidate caches.
+const analyzerVersion = "1.0.0"
+
 type Config struct {
 	CodePath          string
 	OutputFormat      string
 	SeverityThreshold string
 	FailOnIssues      bool
 	Verbose           bool
+	CacheDir          string
+	NoCache           bool
+	CacheMaxAge       time.Duration
+	NoProgress        bool
+	Silent            bool
+	BaselinePath      string
+	WriteBaseline     bool
+	Languages         string
+	MetricsAddr       string
+	PostgresDSN       string
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
+	ExplainDSN        string
+	ExplainDriver     string
 }
 
 func main() {
@@ -31,27 +72,88 @@ func main() {
 		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 	}
 
-	results, err := analyzeCodebase(config)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if config.MetricsAddr != "" {
+		srv := startMetricsServer(config.MetricsAddr)
+		defer srv.Close()
+	}
+
+	if config.ExplainDSN != "" {
+		explainer, err := dbexplain.Open(config.ExplainDriver, config.ExplainDSN)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  missing-index EXPLAIN disabled: %v\n", err)
+		} else {
+			analysis.SetDBExplainer(explainer)
+			defer explainer.Close()
+		}
+	}
+
+	results, cacheStats, aborted, err := analyzeCodebase(ctx, config)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "❌ Analysis failed: %v\n", err)
 		os.Exit(1)
 	}
 
+	if aborted {
+		fmt.Fprintf(os.Stderr, "\n⚠️  aborted after %d file(s) - showing partial results\n", len(results))
+	}
+
+	if config.BaselinePath != "" {
+		if config.WriteBaseline {
+			if err := writeBaseline(config.BaselinePath, results); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ Failed to write baseline: %v\n", err)
+				os.Exit(1)
+			}
+			if config.Verbose {
+				fmt.Printf("📝 Wrote baseline to %s\n", config.BaselinePath)
+			}
+		} else {
+			baseline, err := loadBaseline(config.BaselinePath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "❌ Failed to read baseline: %v\n", err)
+				os.Exit(1)
+			}
+			applyBaseline(results, baseline)
+		}
+	}
+
+	if config.PostgresDSN != "" {
+		pool := store.PoolConfig{
+			MaxOpenConns:    config.DBMaxOpenConns,
+			MaxIdleConns:    config.DBMaxIdleConns,
+			ConnMaxLifetime: config.DBConnMaxLifetime,
+		}
+		persistResults(ctx, config.PostgresDSN, pool, results)
+	}
+
+	if cfg, ok := archive.ConfigFromEnv(); ok {
+		uploadResults(ctx, cfg, results)
+	}
+
 	// Output results
 	switch config.OutputFormat {
 	case "json":
 		outputJSON(results)
 	case "sarif":
 		outputSARIF(results)
+	case "junit":
+		outputJUnit(results)
 	case "github":
 		outputGitHub(results)
+	case "pr-review":
+		if err := outputPRReview(ctx, results); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to post PR review: %v\n", err)
+			os.Exit(1)
+		}
 	default:
 		outputMarkdown(results)
 	}
 
 	// Set GitHub Action outputs if running in GitHub Actions
 	if os.Getenv("GITHUB_ACTIONS") == "true" {
-		setGitHubActionOutputs(results)
+		setGitHubActionOutputs(results, cacheStats)
 	}
 
 	// Check if we should fail the build
@@ -64,16 +166,57 @@ func main() {
 		fmt.Printf("✅ Analysis complete! Found %d total issues across %d files\n",
 			countTotalIssues(results), len(results))
 	}
+
+	if config.MetricsAddr != "" {
+		if config.Verbose {
+			fmt.Printf("📈 serving metrics at http://%s/metrics - press Ctrl+C to stop\n", config.MetricsAddr)
+		}
+		<-ctx.Done()
+	}
+}
+
+// startMetricsServer starts an HTTP server exposing the Prometheus metrics
+// collected by internal/analysis/metrics at /metrics, so a scraper can track
+// performance/security posture across repeated runs instead of only seeing a
+// single report. Listen failures are logged but non-fatal: metrics are a
+// bonus, not a reason to abort analysis.
+func startMetricsServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	srv := &http.Server{Addr: addr, Handler: mux}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fmt.Fprintf(os.Stderr, "⚠️  metrics server error: %v\n", err)
+		}
+	}()
+
+	return srv
 }
 
 func parseFlags() Config {
 	var config Config
 
 	flag.StringVar(&config.CodePath, "path", ".", "Path to analyze")
-	flag.StringVar(&config.OutputFormat, "format", "markdown", "Output format (markdown, json, sarif, github)")
+	flag.StringVar(&config.OutputFormat, "format", "markdown", "Output format (markdown, json, sarif, junit, github, pr-review)")
 	flag.StringVar(&config.SeverityThreshold, "threshold", "medium", "Severity threshold (low, medium, high, critical)")
 	flag.BoolVar(&config.FailOnIssues, "fail-on-issues", false, "Fail on issues above threshold")
 	flag.BoolVar(&config.Verbose, "verbose", false, "Verbose output")
+	flag.StringVar(&config.CacheDir, "cache-dir", "", "Directory for the analysis cache (default: $XDG_CACHE_HOME/api-perf-analyzer/v1)")
+	flag.BoolVar(&config.NoCache, "no-cache", false, "Disable the analysis cache")
+	flag.DurationVar(&config.CacheMaxAge, "cache-max-age", 0, "Discard cache entries older than this (e.g. 168h); 0 means never expire")
+	flag.BoolVar(&config.NoProgress, "no-progress", false, "Disable the progress bar")
+	flag.BoolVar(&config.Silent, "silent", false, "Suppress all non-essential output, including the progress bar")
+	flag.StringVar(&config.BaselinePath, "baseline", "", "Path to a baseline file; matching findings are marked suppressed")
+	flag.BoolVar(&config.WriteBaseline, "write-baseline", false, "Write all current findings to -baseline instead of analyzing against it")
+	flag.StringVar(&config.Languages, "languages", "", fmt.Sprintf("Comma-separated languages to analyze (default: all of %s)", strings.Join(langs.Names(), ", ")))
+	flag.StringVar(&config.MetricsAddr, "metrics-addr", "", "If set, serve Prometheus metrics at this address (e.g. :9090) and keep running after analysis until interrupted")
+	flag.StringVar(&config.PostgresDSN, "postgres-dsn", "", "If set, record every result in this Postgres database for historical trend queries")
+	flag.IntVar(&config.DBMaxOpenConns, "db-max-open-conns", 10, "Max open connections to -postgres-dsn")
+	flag.IntVar(&config.DBMaxIdleConns, "db-max-idle-conns", 5, "Max idle connections to -postgres-dsn")
+	flag.DurationVar(&config.DBConnMaxLifetime, "db-conn-max-lifetime", time.Hour, "Max lifetime of a connection to -postgres-dsn")
+	flag.StringVar(&config.ExplainDSN, "explain-dsn", "", "If set, run real EXPLAIN queries against this database for missing-index detection (falls back to the WHERE-clause heuristic when unset)")
+	flag.StringVar(&config.ExplainDriver, "explain-driver", "postgres", "database/sql driver name for -explain-dsn")
 	flag.Parse()
 
 	// Override with environment variables if present (for GitHub Actions)
@@ -93,23 +236,71 @@ func parseFlags() Config {
 	return config
 }
 
-func analyzeCodebase(config Config) ([]analysis.FileAnalysis, error) {
-	var results []analysis.FileAnalysis
+// isEligibleFile reports whether path should be analyzed: a file claimed by
+// one of the allowed language analyzers, outside vendor/ and .git/, and (for
+// Go specifically) not a _test.go file.
+func isEligibleFile(path string, allowed map[string]bool) bool {
+	if strings.Contains(path, "vendor/") || strings.Contains(path, ".git/") {
+		return false
+	}
+
+	lang, ok := langs.ForPath(path)
+	if !ok || !allowed[lang.Name()] {
+		return false
+	}
 
-	err := filepath.WalkDir(config.CodePath, func(path string, d fs.DirEntry, err error) error {
+	return lang.Name() != "go" || !strings.Contains(path, "_test.go")
+}
+
+// countEligibleFiles walks root once just to size the progress bar before
+// the real (potentially much slower) analysis pass starts.
+func countEligibleFiles(root string, allowed map[string]bool) (int, error) {
+	count := 0
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
+		if isEligibleFile(path, allowed) {
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
 
-		// Only analyze Go files
-		if !strings.HasSuffix(path, ".go") {
-			return nil
+func analyzeCodebase(ctx context.Context, config Config) (results []analysis.FileAnalysis, cacheStats *cache.Stats, aborted bool, err error) {
+	store, cacheStats := setupCache(config)
+
+	selected, err := langs.Parse(config.Languages)
+	if err != nil {
+		return nil, cacheStats, false, err
+	}
+	allowed := make(map[string]bool, len(selected))
+	for _, name := range selected {
+		allowed[name] = true
+	}
+
+	total, err := countEligibleFiles(config.CodePath, allowed)
+	if err != nil {
+		return nil, cacheStats, false, err
+	}
+
+	bar := newProgressBar(config, total)
+	if bar != nil {
+		defer bar.Finish()
+	}
+
+	err = filepath.WalkDir(config.CodePath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if ctx.Err() != nil {
+			aborted = true
+			return filepath.SkipAll
 		}
 
-		// Skip vendor, .git, and test files for faster analysis
-		if strings.Contains(path, "vendor/") ||
-			strings.Contains(path, ".git/") ||
-			strings.Contains(path, "_test.go") {
+		if !isEligibleFile(path, allowed) {
 			return nil
 		}
 
@@ -117,22 +308,265 @@ func analyzeCodebase(config Config) ([]analysis.FileAnalysis, error) {
 			fmt.Printf("📁 Analyzing: %s\n", path)
 		}
 
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("failed to read %s: %w", path, err)
+		content, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return fmt.Errorf("failed to read %s: %w", path, readErr)
 		}
 
-		analysisResult := analysis.AnalyzeCode(string(content), "go", path)
+		analysisResult, fromCache := analyzeWithCache(ctx, store, cacheStats, content, path)
+		if config.Verbose && store != nil {
+			if fromCache {
+				fmt.Printf("   ♻️  cache hit\n")
+			} else {
+				fmt.Printf("   🔍 cache miss, analyzed\n")
+			}
+		}
 
 		results = append(results, analysis.FileAnalysis{
 			FilePath: path,
 			Analysis: analysisResult,
 		})
 
+		if bar != nil {
+			bar.Increment()
+		}
+
 		return nil
 	})
+	if err != nil {
+		return nil, cacheStats, aborted, err
+	}
+
+	if config.Verbose && store != nil {
+		fmt.Printf("📦 cache: %d hit(s), %d miss(es)\n", cacheStats.Hits, cacheStats.Misses)
+	}
+
+	if aborted {
+		// Partial results only: skip the (slower, whole-package) AST/taint
+		// passes below so we return to the caller promptly.
+		return results, cacheStats, aborted, nil
+	}
+
+	if !allowed["go"] {
+		// The AST engine and taint analyzer both work on type-checked Go
+		// packages; skip them entirely when Go wasn't selected.
+		return results, cacheStats, aborted, nil
+	}
+
+	pkgs, loadErr := astengine.LoadPackages(config.CodePath, "./...")
+	if loadErr != nil || packages.PrintErrors(pkgs) > 0 {
+		if config.Verbose {
+			fmt.Printf("⚠️  skipping AST/taint checks (%v)\n", loadErr)
+		}
+		return results, cacheStats, aborted, nil
+	}
+
+	byPath := make(map[string]*analysis.FileAnalysis, len(results))
+	for i := range results {
+		byPath[results[i].FilePath] = &results[i]
+	}
+
+	enrichWithASTEngine(pkgs, byPath)
+	enrichWithTaint(pkgs, byPath)
+
+	return results, cacheStats, aborted, nil
+}
+
+// newProgressBar returns nil when progress reporting is disabled, silenced,
+// or stdout isn't a terminal (a plain pipe/file, or GITHUB_ACTIONS=true,
+// where a redrawing bar just produces noisy log lines).
+func newProgressBar(config Config, total int) *pb.ProgressBar {
+	if config.NoProgress || config.Silent || os.Getenv("GITHUB_ACTIONS") == "true" || !isTerminal(os.Stdout) {
+		return nil
+	}
+
+	bar := pb.New(total)
+	bar.SetTemplateString(`{{ string . "prefix" }}{{ counters . }} {{ bar . }} {{ speed . "%s files/s" }} {{ etime . }}`)
+	bar.Start()
+	return bar
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// setupCache builds the on-disk cache described by config, unless
+// config.NoCache is set. Failure to initialize the cache (e.g. an
+// unwritable directory) is non-fatal: analysis just runs uncached.
+func setupCache(config Config) (cache.Store, *cache.Stats) {
+	stats := &cache.Stats{}
+	if config.NoCache {
+		return nil, stats
+	}
+
+	dir := config.CacheDir
+	if dir == "" {
+		var err error
+		dir, err = cache.DefaultDir()
+		if err != nil {
+			if config.Verbose {
+				fmt.Printf("⚠️  cache disabled: %v\n", err)
+			}
+			return nil, stats
+		}
+	}
+
+	store, err := cache.NewFileStore(dir, config.CacheMaxAge)
+	if err != nil {
+		if config.Verbose {
+			fmt.Printf("⚠️  cache disabled: %v\n", err)
+		}
+		return nil, stats
+	}
+	return store, stats
+}
+
+// analyzeWithCache runs path's language analyzer over content, serving a
+// cached result when one exists for this exact content + analyzer version +
+// ruleset, and populating the cache on a miss.
+func analyzeWithCache(ctx context.Context, store cache.Store, stats *cache.Stats, content []byte, path string) (analysis.CodeAnalysis, bool) {
+	if store == nil {
+		return runLanguageAnalyzer(ctx, path, content), false
+	}
+
+	key := cache.Key(content, analyzerVersion, rulesetHash())
+
+	if cached, ok, err := store.Get(key); err == nil && ok {
+		var result analysis.CodeAnalysis
+		if err := json.Unmarshal(cached, &result); err == nil {
+			stats.Hits++
+			return result, true
+		}
+	}
+
+	stats.Misses++
+	result := runLanguageAnalyzer(ctx, path, content)
+	if data, err := json.Marshal(result); err == nil {
+		_ = store.Put(key, data)
+	}
+	return result, false
+}
+
+// runLanguageAnalyzer runs the LanguageAnalyzer registered for path's
+// extension. isEligibleFile already filtered out paths with no registered
+// analyzer, so lang is always found here. Inline suppression comments are
+// only understood for Go today, since they're parsed with go/parser.
+func runLanguageAnalyzer(ctx context.Context, path string, content []byte) analysis.CodeAnalysis {
+	start := time.Now()
+
+	lang, _ := langs.ForPath(path)
+	result, _ := lang.Analyze(ctx, path, content)
+	if lang.Name() == "go" {
+		applyInlineSuppressions(path, content, &result)
+	}
+
+	metrics.Observe(result, time.Since(start))
+	return result
+}
+
+// rulesetHash fingerprints the ids in the SARIF rule catalog so that adding
+// or removing a detection rule busts every cached result, even when
+// analyzerVersion wasn't bumped. Only rule ids are hashed: changing an
+// existing rule's metadata leaves the hash unchanged.
+func rulesetHash() string {
+	ids := make([]string, 0, len(knownSARIFRules()))
+	for id := range knownSARIFRules() {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	h := sha256.New()
+	for _, id := range ids {
+		h.Write([]byte(id))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// enrichWithTaint builds SSA for pkgs and runs the taint analyzer across
+// every function, recording confirmed source-to-sink flows as SecurityIssues
+// with a populated DataFlow path, merged into the matching FileAnalysis by
+// the sink call's file.
+func enrichWithTaint(pkgs []*packages.Package, byPath map[string]*analysis.FileAnalysis) {
+	_, ssaPkgs := taint.BuildSSA(pkgs)
+	analyzer := taint.NewAnalyzer()
+
+	for _, fn := range taint.Functions(ssaPkgs) {
+		for _, finding := range analyzer.Run(fn) {
+			if len(finding.Path) == 0 {
+				continue
+			}
+			sinkStep := finding.Path[len(finding.Path)-1]
+			fa, ok := byPath[sinkStep.Position.Filename]
+			if !ok {
+				continue
+			}
+
+			dataFlow := make([]analysis.Step, 0, len(finding.Path))
+			for _, step := range finding.Path {
+				dataFlow = append(dataFlow, analysis.Step{
+					Description: step.Description,
+					FilePath:    step.Position.Filename,
+					LineNumber:  step.Position.Line,
+				})
+			}
+
+			fa.Analysis.SecurityIssues = append(fa.Analysis.SecurityIssues, analysis.SecurityIssue{
+				Type:        "tainted_data_flow",
+				Description: fmt.Sprintf("tainted value reaches %s", finding.SinkName),
+				Severity:    finding.Severity,
+				LineNumber:  sinkStep.Position.Line,
+				Suggestion:  "validate/sanitize the input before it reaches " + finding.SinkName,
+				DataFlow:    dataFlow,
+			})
+		}
+	}
+}
+
+// enrichWithASTEngine runs the astengine checker registry across every
+// already-loaded package concurrently, merging its (AST/type-precise)
+// findings into the matching FileAnalysis entries produced by the
+// substring-based pass above.
+func enrichWithASTEngine(pkgs []*packages.Package, byPath map[string]*analysis.FileAnalysis) {
+	workers := runtime.GOMAXPROCS(0)
+	pkgCh := make(chan *packages.Package)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pkg := range pkgCh {
+				findings := astengine.RunAll(pkg)
+
+				mu.Lock()
+				for _, f := range findings {
+					fa, ok := byPath[f.Pos.Filename]
+					if !ok {
+						continue
+					}
+					fa.Analysis.SecurityIssues = append(fa.Analysis.SecurityIssues, analysis.SecurityIssue{
+						Type:        f.CheckerName,
+						Description: f.Message,
+						Severity:    f.Severity,
+						LineNumber:  f.Pos.Line,
+						Suggestion:  "flagged by astengine checker " + f.CheckerName,
+					})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
 
-	return results, err
+	for _, pkg := range pkgs {
+		pkgCh <- pkg
+	}
+	close(pkgCh)
+	wg.Wait()
 }
 
 func outputMarkdown(results []analysis.FileAnalysis) {
@@ -144,19 +578,28 @@ func outputMarkdown(results []analysis.FileAnalysis) {
 	securityIssues := 0
 	performanceIssues := 0
 
-	// Summary stats
+	// Summary stats (suppressed findings are tracked separately below and
+	// don't count toward the headline numbers)
 	for _, result := range results {
-		totalIssues += len(result.Analysis.PerformanceHints) + len(result.Analysis.SecurityIssues)
-		performanceIssues += len(result.Analysis.PerformanceHints)
-		securityIssues += len(result.Analysis.SecurityIssues)
-
 		for _, hint := range result.Analysis.PerformanceHints {
+			if hint.Suppressed {
+				continue
+			}
+			totalIssues++
+			performanceIssues++
 			if hint.Severity == "critical" {
 				criticalIssues++
 			} else if hint.Severity == "high" {
 				highIssues++
 			}
 		}
+		for _, issue := range result.Analysis.SecurityIssues {
+			if issue.Suppressed {
+				continue
+			}
+			totalIssues++
+			securityIssues++
+		}
 	}
 
 	// Overall Performance Score
@@ -189,15 +632,18 @@ func outputMarkdown(results []analysis.FileAnalysis) {
 
 	// File-by-file analysis
 	for _, result := range results {
-		if len(result.Analysis.PerformanceHints) > 0 || len(result.Analysis.SecurityIssues) > 0 {
-			fmt.Printf("## 📄 %s\n\n", result.FilePath)
+		if countActive(result.Analysis.PerformanceHints) > 0 || countActiveIssues(result.Analysis.SecurityIssues) > 0 {
+			fmt.Printf("## 📄 %s _(%s)_\n\n", result.FilePath, languageOf(result.FilePath))
 			fmt.Printf("**Performance Score:** %d/100 (%s)\n\n",
 				result.Analysis.PerformanceScore, result.Analysis.PerformanceGrade)
 
 			// Performance Issues
-			if len(result.Analysis.PerformanceHints) > 0 {
+			if countActive(result.Analysis.PerformanceHints) > 0 {
 				fmt.Printf("### ⚡ Performance Issues\n\n")
 				for _, hint := range result.Analysis.PerformanceHints {
+					if hint.Suppressed {
+						continue
+					}
 					severityEmoji := getSeverityEmoji(hint.Severity)
 					fmt.Printf("#### %s %s\n", severityEmoji, hint.Issue)
 					fmt.Printf("**Impact:** %s\n\n", hint.Impact)
@@ -212,9 +658,12 @@ func outputMarkdown(results []analysis.FileAnalysis) {
 			}
 
 			// Security Issues
-			if len(result.Analysis.SecurityIssues) > 0 {
+			if countActiveIssues(result.Analysis.SecurityIssues) > 0 {
 				fmt.Printf("### 🔒 Security Issues\n\n")
 				for _, issue := range result.Analysis.SecurityIssues {
+					if issue.Suppressed {
+						continue
+					}
 					severityEmoji := getSeverityEmoji(issue.Severity)
 					fmt.Printf("#### %s %s\n", severityEmoji, strings.ReplaceAll(issue.Type, "_", " "))
 					fmt.Printf("**Description:** %s\n\n", issue.Description)
@@ -222,6 +671,13 @@ func outputMarkdown(results []analysis.FileAnalysis) {
 					if issue.LineNumber > 0 {
 						fmt.Printf("**Line:** %d\n\n", issue.LineNumber)
 					}
+					if len(issue.DataFlow) > 0 {
+						fmt.Printf("**Data flow:**\n\n")
+						for i, step := range issue.DataFlow {
+							fmt.Printf("%d. %s (`%s:%d`)\n", i+1, step.Description, step.FilePath, step.LineNumber)
+						}
+						fmt.Printf("\n")
+					}
 				}
 			}
 
@@ -233,10 +689,70 @@ func outputMarkdown(results []analysis.FileAnalysis) {
 		fmt.Printf("## 🎉 Excellent!\n\nNo performance or security issues detected. Your API is well-optimized!\n\n")
 	}
 
+	printKnownIssues(results)
+
 	fmt.Printf("---\n")
 	fmt.Printf("*Generated by [API Performance Analyzer](https://github.com/marketplace/actions/api-performance-analyzer)*\n")
 }
 
+func countActive(hints []analysis.PerformanceHint) int {
+	n := 0
+	for _, h := range hints {
+		if !h.Suppressed {
+			n++
+		}
+	}
+	return n
+}
+
+func countActiveIssues(issues []analysis.SecurityIssue) int {
+	n := 0
+	for _, i := range issues {
+		if !i.Suppressed {
+			n++
+		}
+	}
+	return n
+}
+
+// languageOf returns the registered language name for path, or "unknown" if
+// no LanguageAnalyzer claims its extension.
+func languageOf(path string) string {
+	lang, ok := langs.ForPath(path)
+	if !ok {
+		return "unknown"
+	}
+	return lang.Name()
+}
+
+// printKnownIssues renders every baseline/inline-suppressed finding inside a
+// collapsed <details> block, so reviewers can still see them without the
+// headline counts or exit code being affected.
+func printKnownIssues(results []analysis.FileAnalysis) {
+	var lines []string
+	for _, result := range results {
+		for _, hint := range result.Analysis.PerformanceHints {
+			if hint.Suppressed {
+				lines = append(lines, fmt.Sprintf("- `%s`: %s (line %d)", result.FilePath, hint.Issue, hint.LineNumber))
+			}
+		}
+		for _, issue := range result.Analysis.SecurityIssues {
+			if issue.Suppressed {
+				lines = append(lines, fmt.Sprintf("- `%s`: %s (line %d)", result.FilePath, strings.ReplaceAll(issue.Type, "_", " "), issue.LineNumber))
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	fmt.Printf("<details>\n<summary>📋 Known issues (%d, suppressed by baseline or inline comment)</summary>\n\n", len(lines))
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	fmt.Printf("\n</details>\n\n")
+}
+
 func getSeverityEmoji(severity string) string {
 	switch severity {
 	case "critical":
@@ -258,6 +774,9 @@ func outputJSON(results []analysis.FileAnalysis) {
 }
 
 func outputSARIF(results []analysis.FileAnalysis) {
+	catalog := newSARIFRuleCatalog()
+	sarifResults := convertToSARIFResults(results, catalog)
+
 	// SARIF format for GitHub Security tab integration
 	sarif := map[string]interface{}{
 		"version": "2.1.0",
@@ -267,11 +786,13 @@ func outputSARIF(results []analysis.FileAnalysis) {
 				"tool": map[string]interface{}{
 					"driver": map[string]interface{}{
 						"name":           "API Performance Analyzer",
-						"version":        "1.0.0",
+						"version":        analyzerVersion,
 						"informationUri": "https://github.com/marketplace/actions/api-performance-analyzer",
+						"rules":          catalog.rulesUsed(),
 					},
 				},
-				"results": convertToSARIFResults(results),
+				"artifacts": artifactsFor(results),
+				"results":   sarifResults,
 			},
 		},
 	}
@@ -280,9 +801,82 @@ func outputSARIF(results []analysis.FileAnalysis) {
 	fmt.Println(string(jsonData))
 }
 
+// junitTestSuites is the root element of a JUnit XML report: one
+// <testsuite> per finding category (security, performance), so CI systems
+// that already surface JUnit results (Jenkins, GitLab, GitHub Actions test
+// reporters) can display analyzer findings as test failures.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// outputJUnit renders results as JUnit XML: every SecurityIssue and
+// PerformanceHint becomes a <testcase> with a <failure>, grouped into a
+// "security" and a "performance" <testsuite>.
+func outputJUnit(results []analysis.FileAnalysis) {
+	security := junitTestSuite{Name: "security"}
+	performance := junitTestSuite{Name: "performance"}
+
+	for _, result := range results {
+		for _, issue := range result.Analysis.SecurityIssues {
+			security.Tests++
+			security.Failures++
+			security.Cases = append(security.Cases, junitTestCase{
+				ClassName: result.FilePath,
+				Name:      fmt.Sprintf("%s (line %d)", strings.ReplaceAll(issue.Type, "_", " "), issue.LineNumber),
+				Failure: &junitFailure{
+					Message: issue.Description,
+					Text:    issue.Suggestion,
+				},
+			})
+		}
+
+		for _, hint := range result.Analysis.PerformanceHints {
+			performance.Tests++
+			performance.Failures++
+			performance.Cases = append(performance.Cases, junitTestCase{
+				ClassName: result.FilePath,
+				Name:      fmt.Sprintf("%s (line %d)", hint.Issue, hint.LineNumber),
+				Failure: &junitFailure{
+					Message: hint.Impact,
+					Text:    hint.Solution,
+				},
+			})
+		}
+	}
+
+	suites := junitTestSuites{Suites: []junitTestSuite{security, performance}}
+	out, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to render JUnit XML: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(xml.Header + string(out))
+}
+
 func outputGitHub(results []analysis.FileAnalysis) {
 	// GitHub-specific output format for annotations
 	for _, result := range results {
+		lang := languageOf(result.FilePath)
+
 		for _, hint := range result.Analysis.PerformanceHints {
 			level := "warning"
 			if hint.Severity == "critical" || hint.Severity == "high" {
@@ -294,8 +888,8 @@ func outputGitHub(results []analysis.FileAnalysis) {
 				line = 1
 			}
 
-			fmt.Printf("::%s file=%s,line=%d::%s: %s\n",
-				level, result.FilePath, line, hint.Issue, hint.Impact)
+			fmt.Printf("::%s file=%s,line=%d::[%s] %s: %s\n",
+				level, result.FilePath, line, lang, hint.Issue, hint.Impact)
 		}
 
 		for _, issue := range result.Analysis.SecurityIssues {
@@ -309,52 +903,234 @@ func outputGitHub(results []analysis.FileAnalysis) {
 				line = 1
 			}
 
-			fmt.Printf("::%s file=%s,line=%d::%s: %s\n",
-				level, result.FilePath, line, issue.Type, issue.Description)
+			fmt.Printf("::%s file=%s,line=%d::[%s] %s: %s\n",
+				level, result.FilePath, line, lang, issue.Type, issue.Description)
+		}
+	}
+}
+
+// sarifRule describes one entry in the SARIF tool.driver.rules[] catalog.
+type sarifRule struct {
+	ID               string
+	Name             string
+	ShortDescription string
+	FullDescription  string
+	HelpURI          string
+	Level            string
+	Tags             []string
+}
+
+// sarifRuleCatalog tracks the rules actually referenced by a run so the
+// emitted rules[] array only contains entries GitHub Code Scanning needs.
+type sarifRuleCatalog struct {
+	known map[string]sarifRule
+	used  []string
+	index map[string]int
+}
+
+func newSARIFRuleCatalog() *sarifRuleCatalog {
+	return &sarifRuleCatalog{known: knownSARIFRules(), index: map[string]int{}}
+}
+
+// indexFor returns the ruleIndex for id, registering it in the catalog
+// (in first-seen order) the first time it's referenced.
+func (c *sarifRuleCatalog) indexFor(id string) int {
+	if idx, ok := c.index[id]; ok {
+		return idx
+	}
+	idx := len(c.used)
+	c.used = append(c.used, id)
+	c.index[id] = idx
+	return idx
+}
+
+func (c *sarifRuleCatalog) rulesUsed() []map[string]interface{} {
+	rules := make([]map[string]interface{}, 0, len(c.used))
+	for _, id := range c.used {
+		rule, ok := c.known[id]
+		if !ok {
+			rule = sarifRule{ID: id, Name: id, ShortDescription: id, FullDescription: id, Level: "warning"}
 		}
+		rules = append(rules, map[string]interface{}{
+			"id":   rule.ID,
+			"name": rule.Name,
+			"shortDescription": map[string]string{
+				"text": rule.ShortDescription,
+			},
+			"fullDescription": map[string]string{
+				"text": rule.FullDescription,
+			},
+			"helpUri": rule.HelpURI,
+			"defaultConfiguration": map[string]string{
+				"level": rule.Level,
+			},
+			"properties": map[string]interface{}{
+				"tags": rule.Tags,
+			},
+		})
+	}
+	return rules
+}
+
+// knownSARIFRules catalogs metadata for every ruleId the analyzer can emit.
+// Rule ids not found here (e.g. ones added by future detectors) fall back to
+// a generic entry in rulesUsed so the catalog never drops a referenced rule.
+func knownSARIFRules() map[string]sarifRule {
+	rules := []sarifRule{
+		{
+			ID:               "performance/Potential_N+1_Query_Pattern",
+			Name:             "N1QueryPattern",
+			ShortDescription: "Potential N+1 query pattern",
+			FullDescription:  "A database call was found inside a for loop, which typically executes one query per iteration instead of one query total.",
+			HelpURI:          "https://github.com/marketplace/actions/api-performance-analyzer#n1-queries",
+			Level:            "error",
+			Tags:             []string{"performance"},
+		},
+		{
+			ID:               "performance/N+1_Query_in_Range_Loop",
+			Name:             "N1QueryInRangeLoop",
+			ShortDescription: "N+1 query in range loop",
+			FullDescription:  "A database call was found inside a range loop, which typically executes one query per element instead of one batched query.",
+			HelpURI:          "https://github.com/marketplace/actions/api-performance-analyzer#n1-queries",
+			Level:            "error",
+			Tags:             []string{"performance"},
+		},
+		{
+			ID:               "performance/GORM_N+1_Query_with_Related()",
+			Name:             "GORMRelatedN1",
+			ShortDescription: "GORM N+1 query via Related()",
+			FullDescription:  "Each call to GORM's Related() inside a loop issues a separate query; Preload() fetches the association in a single query instead.",
+			HelpURI:          "https://github.com/marketplace/actions/api-performance-analyzer#n1-queries",
+			Level:            "error",
+			Tags:             []string{"performance"},
+		},
+		{
+			ID:               "performance/Potential_Missing_Database_Index",
+			Name:             "MissingIndex",
+			ShortDescription: "Potential missing database index",
+			FullDescription:  "A query filters on a column that commonly lacks an index, causing a full table scan as the table grows.",
+			HelpURI:          "https://github.com/marketplace/actions/api-performance-analyzer#missing-indexes",
+			Level:            "warning",
+			Tags:             []string{"performance"},
+		},
+		{
+			ID:               "performance/Missing_Database_Index",
+			Name:             "MissingIndexExplained",
+			ShortDescription: "Missing database index (EXPLAIN-confirmed)",
+			FullDescription:  "A real EXPLAIN against the configured database showed a sequential scan over a large estimated row count for this query, confirming it would benefit from an index.",
+			HelpURI:          "https://github.com/marketplace/actions/api-performance-analyzer#missing-indexes",
+			Level:            "error",
+			Tags:             []string{"performance"},
+		},
+		{
+			ID:               "performance/Large_Dataset_Response_Without_Pagination",
+			Name:             "UnpaginatedResponse",
+			ShortDescription: "Large dataset response without pagination",
+			FullDescription:  "A query result is serialized and returned directly without any Limit/Offset, risking slow responses and high memory usage as the table grows.",
+			HelpURI:          "https://github.com/marketplace/actions/api-performance-analyzer#pagination",
+			Level:            "warning",
+			Tags:             []string{"performance"},
+		},
+		{
+			ID:               "performance/Expensive_Operation_Without_Caching",
+			Name:             "MissingCache",
+			ShortDescription: "Expensive operation without caching",
+			FullDescription:  "An aggregation or report-style operation was found with no caching layer, so repeated requests recompute it from scratch.",
+			HelpURI:          "https://github.com/marketplace/actions/api-performance-analyzer#caching",
+			Level:            "note",
+			Tags:             []string{"performance"},
+		},
+		{
+			ID:               "performance/In-memory_data_storage",
+			Name:             "InMemoryStorage",
+			ShortDescription: "In-memory data storage",
+			FullDescription:  "Application state is held in a package-level slice/map instead of a database, so data is lost on restart and can't scale past one instance.",
+			HelpURI:          "https://github.com/marketplace/actions/api-performance-analyzer#persistence",
+			Level:            "warning",
+			Tags:             []string{"performance"},
+		},
+		{
+			ID:               "performance/No_database_connection_pooling",
+			Name:             "NoConnectionPooling",
+			ShortDescription: "No database connection pooling",
+			FullDescription:  "sql.Open was called without configuring SetMaxOpenConns/SetMaxIdleConns/SetConnMaxLifetime, risking resource exhaustion under load.",
+			HelpURI:          "https://github.com/marketplace/actions/api-performance-analyzer#connection-pooling",
+			Level:            "error",
+			Tags:             []string{"performance"},
+		},
+		{
+			ID:               "security/sql_injection_risk",
+			Name:             "SQLInjectionRisk",
+			ShortDescription: "Raw SQL query built from string concatenation",
+			FullDescription:  "A SQL statement is assembled from string concatenation or fmt.Sprintf rather than parameterized placeholders, allowing attacker-controlled input to alter the query.",
+			HelpURI:          "https://cwe.mitre.org/data/definitions/89.html",
+			Level:            "error",
+			Tags:             []string{"security", "CWE-89"},
+		},
+		{
+			ID:               "security/potential_hardcoded_secrets",
+			Name:             "HardcodedSecrets",
+			ShortDescription: "Potential hardcoded credentials",
+			FullDescription:  "A password, API key, secret, or token appears to be hardcoded in source rather than loaded from configuration/environment.",
+			HelpURI:          "https://cwe.mitre.org/data/definitions/798.html",
+			Level:            "error",
+			Tags:             []string{"security", "CWE-798"},
+		},
+		{
+			ID:               "security/missing_cors",
+			Name:             "MissingCORS",
+			ShortDescription: "No CORS middleware detected",
+			FullDescription:  "The handler code has no CORS middleware configured, which can cause unexpected browser behavior or overly permissive cross-origin access if added later without review.",
+			HelpURI:          "https://github.com/marketplace/actions/api-performance-analyzer#cors",
+			Level:            "warning",
+			Tags:             []string{"security"},
+		},
+		{
+			ID:               "security/insufficient_error_handling",
+			Name:             "InsufficientErrorHandling",
+			ShortDescription: "JSON binding without error response",
+			FullDescription:  "BindJSON/ShouldBindJSON is used without returning a StatusBadRequest on failure, so malformed input is silently accepted.",
+			HelpURI:          "https://github.com/marketplace/actions/api-performance-analyzer#error-handling",
+			Level:            "warning",
+			Tags:             []string{"security"},
+		},
+		{
+			ID:               "security/syntax_error",
+			Name:             "SyntaxError",
+			ShortDescription: "Code contains syntax errors",
+			FullDescription:  "The file could not be parsed as valid Go, so most checks could not run against it.",
+			Level:            "error",
+			Tags:             []string{"security"},
+		},
+	}
+
+	known := make(map[string]sarifRule, len(rules))
+	for _, r := range rules {
+		known[r.ID] = r
 	}
+	return known
 }
 
-func convertToSARIFResults(results []analysis.FileAnalysis) []map[string]interface{} {
+func convertToSARIFResults(results []analysis.FileAnalysis, catalog *sarifRuleCatalog) []map[string]interface{} {
 	var sarifResults []map[string]interface{}
 
 	for _, result := range results {
 		// Performance issues
 		for _, hint := range result.Analysis.PerformanceHints {
-			sarifResult := map[string]interface{}{
-				"ruleId":  "performance/" + strings.ReplaceAll(hint.Issue, " ", "_"),
-				"level":   mapSeverityToSARIF(hint.Severity),
-				"message": map[string]string{"text": hint.Impact},
-				"locations": []map[string]interface{}{
-					{
-						"physicalLocation": map[string]interface{}{
-							"artifactLocation": map[string]string{"uri": result.FilePath},
-							"region": map[string]int{
-								"startLine": maxInt(hint.LineNumber, 1),
-							},
-						},
-					},
-				},
-			}
-			sarifResults = append(sarifResults, sarifResult)
+			ruleID := ruleIDForHint(hint)
+			sarifResults = append(sarifResults, sarifResultFor(
+				ruleID, catalog.indexFor(ruleID), mapSeverityToSARIF(hint.Severity),
+				hint.Impact, result.FilePath, hint.LineNumber))
 		}
 
 		// Security issues
 		for _, issue := range result.Analysis.SecurityIssues {
-			sarifResult := map[string]interface{}{
-				"ruleId":  "security/" + issue.Type,
-				"level":   mapSeverityToSARIF(issue.Severity),
-				"message": map[string]string{"text": issue.Description},
-				"locations": []map[string]interface{}{
-					{
-						"physicalLocation": map[string]interface{}{
-							"artifactLocation": map[string]string{"uri": result.FilePath},
-							"region": map[string]int{
-								"startLine": maxInt(issue.LineNumber, 1),
-							},
-						},
-					},
-				},
+			ruleID := ruleIDForIssue(issue)
+			sarifResult := sarifResultFor(
+				ruleID, catalog.indexFor(ruleID), mapSeverityToSARIF(issue.Severity),
+				issue.Description, result.FilePath, issue.LineNumber)
+			if len(issue.DataFlow) > 0 {
+				sarifResult["codeFlows"] = []map[string]interface{}{codeFlowFor(issue.DataFlow)}
 			}
 			sarifResults = append(sarifResults, sarifResult)
 		}
@@ -363,6 +1139,94 @@ func convertToSARIFResults(results []analysis.FileAnalysis) []map[string]interfa
 	return sarifResults
 }
 
+// ruleIDForHint and ruleIDForIssue derive the stable ruleId used for both
+// the SARIF rules catalog and baseline fingerprints, so the two stay in
+// sync by construction instead of by convention.
+func ruleIDForHint(hint analysis.PerformanceHint) string {
+	return "performance/" + strings.ReplaceAll(hint.Issue, " ", "_")
+}
+
+func ruleIDForIssue(issue analysis.SecurityIssue) string {
+	return "security/" + issue.Type
+}
+
+func sarifResultFor(ruleID string, ruleIndex int, level, message, filePath string, lineNumber int) map[string]interface{} {
+	line := maxInt(lineNumber, 1)
+	return map[string]interface{}{
+		"ruleId":    ruleID,
+		"ruleIndex": ruleIndex,
+		"level":     level,
+		"message":   map[string]string{"text": message},
+		"partialFingerprints": map[string]string{
+			"primaryLocationLineHash": fingerprintHash(ruleID, filePath, message),
+		},
+		"locations": []map[string]interface{}{
+			{
+				"physicalLocation": map[string]interface{}{
+					"artifactLocation": map[string]string{"uri": filePath},
+					"region": map[string]int{
+						"startLine":   line,
+						"startColumn": 1,
+						"endLine":     line,
+					},
+				},
+			},
+		},
+	}
+}
+
+// normalizeSnippetRE collapses runs of whitespace so that cosmetic
+// reformatting doesn't change a fingerprint.
+var normalizeSnippetRE = regexp.MustCompile(`\s+`)
+
+// fingerprintHash computes partialFingerprints.primaryLocationLineHash as
+// sha256(ruleId|file|normalized-code-snippet) so GitHub Code Scanning can match
+// the same finding across runs even when line numbers shift.
+func fingerprintHash(ruleID, filePath, snippet string) string {
+	normalized := normalizeSnippetRE.ReplaceAllString(strings.TrimSpace(snippet), " ")
+	sum := sha256.Sum256([]byte(ruleID + "|" + filePath + "|" + normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// codeFlowFor renders a taint DataFlow path as a single-threadFlow SARIF
+// codeFlow so viewers like GitHub Code Scanning can step through source ->
+// sink.
+func codeFlowFor(path []analysis.Step) map[string]interface{} {
+	locations := make([]map[string]interface{}, 0, len(path))
+	for _, step := range path {
+		locations = append(locations, map[string]interface{}{
+			"location": map[string]interface{}{
+				"physicalLocation": map[string]interface{}{
+					"artifactLocation": map[string]string{"uri": step.FilePath},
+					"region":           map[string]int{"startLine": maxInt(step.LineNumber, 1)},
+				},
+				"message": map[string]string{"text": step.Description},
+			},
+		})
+	}
+	return map[string]interface{}{
+		"threadFlows": []map[string]interface{}{
+			{"locations": locations},
+		},
+	}
+}
+
+func artifactsFor(results []analysis.FileAnalysis) []map[string]interface{} {
+	artifacts := make([]map[string]interface{}, 0, len(results))
+	for _, result := range results {
+		content, err := os.ReadFile(result.FilePath)
+		artifact := map[string]interface{}{
+			"location": map[string]string{"uri": result.FilePath},
+		}
+		if err == nil {
+			sum := sha256.Sum256(content)
+			artifact["hashes"] = map[string]string{"sha-256": hex.EncodeToString(sum[:])}
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts
+}
+
 func mapSeverityToSARIF(severity string) string {
 	switch severity {
 	case "critical", "high":
@@ -374,7 +1238,7 @@ func mapSeverityToSARIF(severity string) string {
 	}
 }
 
-func setGitHubActionOutputs(results []analysis.FileAnalysis) {
+func setGitHubActionOutputs(results []analysis.FileAnalysis, cacheStats *cache.Stats) {
 	totalIssues := countTotalIssues(results)
 	avgScore := calculateAverageScore(results)
 
@@ -382,6 +1246,8 @@ func setGitHubActionOutputs(results []analysis.FileAnalysis) {
 	fmt.Printf("::set-output name=issues-found::%d\n", totalIssues)
 	fmt.Printf("::set-output name=performance-score::%d\n", avgScore)
 	fmt.Printf("::set-output name=files-analyzed::%d\n", len(results))
+	fmt.Printf("::set-output name=cache-hits::%d\n", cacheStats.Hits)
+	fmt.Printf("::set-output name=cache-misses::%d\n", cacheStats.Misses)
 
 	// Output JSON results
 	jsonData, _ := json.Marshal(results)
@@ -391,12 +1257,12 @@ func setGitHubActionOutputs(results []analysis.FileAnalysis) {
 func hasHighSeverityIssues(results []analysis.FileAnalysis, threshold string) bool {
 	for _, result := range results {
 		for _, hint := range result.Analysis.PerformanceHints {
-			if shouldFailOnSeverity(hint.Severity, threshold) {
+			if !hint.Suppressed && shouldFailOnSeverity(hint.Severity, threshold) {
 				return true
 			}
 		}
 		for _, issue := range result.Analysis.SecurityIssues {
-			if shouldFailOnSeverity(issue.Severity, threshold) {
+			if !issue.Suppressed && shouldFailOnSeverity(issue.Severity, threshold) {
 				return true
 			}
 		}
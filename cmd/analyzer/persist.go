@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/store"
+)
+
+// persistResults saves every result to a Postgres store at dsn, keyed by the
+// current git SHA, so the store's HistoryForFile/ScoreTrend queries run
+// against it later can see this run. Failure to persist is reported but
+// non-fatal: a broken history database shouldn't block a report a user is
+// actively waiting on.
+func persistResults(ctx context.Context, dsn string, pool store.PoolConfig, results []analysis.FileAnalysis) {
+	pg, err := store.NewPostgres(dsn, pool)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  history not recorded: %v\n", err)
+		return
+	}
+	defer pg.Close()
+
+	sha := gitSHA()
+	for _, result := range results {
+		if err := pg.Save(ctx, result.Analysis, sha); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  failed to record history for %s: %v\n", result.FilePath, err)
+		}
+	}
+}
+
+// gitSHA returns the commit being analyzed: GITHUB_SHA when set (GitHub
+// Actions), otherwise `git rev-parse HEAD` in the current directory, falling
+// back to "unknown" outside a git checkout.
+func gitSHA() string {
+	if sha := os.Getenv("GITHUB_SHA"); sha != "" {
+		return sha
+	}
+
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "unknown"
+	}
+	return strings.TrimSpace(string(out))
+}
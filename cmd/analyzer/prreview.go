@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	"github.com/google/go-github/v56/github"
+	"golang.org/x/oauth2"
+)
+
+// prReviewMarker is embedded (hidden) in every review body this analyzer
+// posts, so a re-run can find its own review and update it instead of
+// piling up duplicate reviews on every push.
+const prReviewMarker = "<!-- api-performance-analyzer:pr-review -->"
+
+// outputPRReview posts findings as a single GitHub pull request review with
+// inline comments, restricted to lines the PR's diff actually touches.
+func outputPRReview(ctx context.Context, results []analysis.FileAnalysis) error {
+	token := os.Getenv("GITHUB_TOKEN")
+	repoSlug := os.Getenv("GITHUB_REPOSITORY")
+	eventPath := os.Getenv("GITHUB_EVENT_PATH")
+	if token == "" || repoSlug == "" || eventPath == "" {
+		return fmt.Errorf("pr-review output requires GITHUB_TOKEN, GITHUB_REPOSITORY and GITHUB_EVENT_PATH")
+	}
+
+	owner, repo, ok := strings.Cut(repoSlug, "/")
+	if !ok {
+		return fmt.Errorf("malformed GITHUB_REPOSITORY %q, expected owner/repo", repoSlug)
+	}
+
+	number, err := pullRequestNumber(eventPath)
+	if err != nil {
+		return err
+	}
+
+	client := github.NewClient(oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})))
+
+	pr, _, err := client.PullRequests.Get(ctx, owner, repo, number)
+	if err != nil {
+		return fmt.Errorf("fetch pull request #%d: %w", number, err)
+	}
+
+	touched, err := touchedLines(ctx, client, owner, repo, number)
+	if err != nil {
+		return fmt.Errorf("list changed files: %w", err)
+	}
+
+	comments := reviewComments(results, touched)
+	if len(comments) == 0 {
+		return nil
+	}
+
+	body := fmt.Sprintf("%s\n## 🚀 API Performance Analyzer\n\n%d finding(s) on lines changed by this PR.\n",
+		prReviewMarker, len(comments))
+
+	existing, err := findExistingReview(ctx, client, owner, repo, number)
+	if err != nil {
+		return fmt.Errorf("list existing reviews: %w", err)
+	}
+	if existing != nil {
+		// The REST API won't let us replace an existing review's inline
+		// comments, so debouncing means refreshing its body rather than
+		// posting a second review with the same findings.
+		_, _, err := client.PullRequests.UpdateReview(ctx, owner, repo, number, existing.GetID(), body)
+		return err
+	}
+
+	_, _, err = client.PullRequests.CreateReview(ctx, owner, repo, number, &github.PullRequestReviewRequest{
+		CommitID: github.String(pr.GetHead().GetSHA()),
+		Body:     github.String(body),
+		Event:    github.String("COMMENT"),
+		Comments: comments,
+	})
+	return err
+}
+
+// pullRequestNumber reads the PR number out of the GitHub Actions event
+// payload. Different event types put it in different places: pull_request
+// and pull_request_target carry it under "pull_request.number", while
+// issue_comment-style events carry it under the top-level "number".
+func pullRequestNumber(eventPath string) (int, error) {
+	data, err := os.ReadFile(eventPath)
+	if err != nil {
+		return 0, fmt.Errorf("read GITHUB_EVENT_PATH: %w", err)
+	}
+
+	var event struct {
+		Number      int `json:"number"`
+		PullRequest struct {
+			Number int `json:"number"`
+		} `json:"pull_request"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return 0, fmt.Errorf("parse GITHUB_EVENT_PATH: %w", err)
+	}
+
+	if event.PullRequest.Number != 0 {
+		return event.PullRequest.Number, nil
+	}
+	if event.Number != 0 {
+		return event.Number, nil
+	}
+	return 0, fmt.Errorf("no pull request number found in %s", eventPath)
+}
+
+// touchedLines maps each changed file's repo-relative path to the set of
+// line numbers its patch added, so findings on untouched lines can be
+// filtered out before they're posted as review comments.
+func touchedLines(ctx context.Context, client *github.Client, owner, repo string, number int) (map[string]map[int]bool, error) {
+	touched := make(map[string]map[int]bool)
+
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			touched[f.GetFilename()] = addedLinesFromPatch(f.GetPatch())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return touched, nil
+}
+
+// addedLinesFromPatch parses a unified diff hunk (as returned in a GitHub
+// PullRequestFile's Patch field) and returns the new-file line numbers it
+// adds.
+func addedLinesFromPatch(patch string) map[int]bool {
+	lines := make(map[int]bool)
+	newLine := 0
+
+	for _, raw := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(raw, "@@ "):
+			newLine = hunkNewStart(raw) - 1
+		case strings.HasPrefix(raw, "+"):
+			newLine++
+			lines[newLine] = true
+		case strings.HasPrefix(raw, "-"):
+			// deleted line: doesn't exist in the new file, don't advance
+		default:
+			newLine++
+		}
+	}
+
+	return lines
+}
+
+// hunkNewStart extracts the starting new-file line number from a hunk
+// header like "@@ -12,5 +15,7 @@ func foo()".
+func hunkNewStart(header string) int {
+	for _, field := range strings.Fields(header) {
+		if !strings.HasPrefix(field, "+") {
+			continue
+		}
+		start, _, _ := strings.Cut(strings.TrimPrefix(field, "+"), ",")
+		n, err := strconv.Atoi(start)
+		if err != nil {
+			return 1
+		}
+		return n
+	}
+	return 1
+}
+
+// reviewComments converts every active (non-suppressed) finding that lands
+// on a touched line into a draft review comment.
+func reviewComments(results []analysis.FileAnalysis, touched map[string]map[int]bool) []*github.DraftReviewComment {
+	var comments []*github.DraftReviewComment
+
+	for _, result := range results {
+		lines, ok := touched[strings.TrimPrefix(result.FilePath, "./")]
+		if !ok {
+			continue
+		}
+
+		for _, hint := range result.Analysis.PerformanceHints {
+			if hint.Suppressed || !lines[hint.LineNumber] {
+				continue
+			}
+			comments = append(comments, &github.DraftReviewComment{
+				Path: github.String(strings.TrimPrefix(result.FilePath, "./")),
+				Line: github.Int(hint.LineNumber),
+				Body: github.String(suggestionBody(hint.Issue, hint.Impact, hint.CodeExample)),
+			})
+		}
+
+		for _, issue := range result.Analysis.SecurityIssues {
+			if issue.Suppressed || !lines[issue.LineNumber] {
+				continue
+			}
+			comments = append(comments, &github.DraftReviewComment{
+				Path: github.String(strings.TrimPrefix(result.FilePath, "./")),
+				Line: github.Int(issue.LineNumber),
+				Body: github.String(suggestionBody(strings.ReplaceAll(issue.Type, "_", " "), issue.Description, "")),
+			})
+		}
+	}
+
+	return comments
+}
+
+// suggestionBody renders a review comment body, appending a GitHub
+// ```suggestion``` block (for one-click apply) when codeExample contains a
+// concrete "✅ Good:" replacement rather than just an illustrative snippet.
+func suggestionBody(title, description, codeExample string) string {
+	body := fmt.Sprintf("**%s**\n\n%s", title, description)
+
+	if fix, ok := extractSuggestion(codeExample); ok {
+		body += "\n\n```suggestion\n" + fix + "\n```\n"
+	}
+
+	return body
+}
+
+// extractSuggestion pulls the replacement snippet out of a CodeExample that
+// follows this repo's "❌ Bad: ... / ✅ Good: ..." convention, returning the
+// code that comes after the "✅ Good:" comment line.
+//
+// It only returns ok=true when that code is a single line: a GitHub
+// suggestion block replaces exactly hint.LineNumber, so a multi-line example
+// (e.g. the pagination hint's multi-line rewrite) would silently overwrite
+// whatever follows the flagged line too. Multi-line examples are illustrative
+// rewrites, not line-local replacements, so they're left out of the
+// suggestion and only shown as prose in the comment body.
+func extractSuggestion(codeExample string) (string, bool) {
+	marker := "✅ Good:"
+	idx := strings.Index(codeExample, marker)
+	if idx == -1 {
+		return "", false
+	}
+
+	rest := codeExample[idx+len(marker):]
+	nl := strings.Index(rest, "\n")
+	if nl == -1 {
+		return "", false
+	}
+
+	fix := strings.TrimSpace(rest[nl+1:])
+	if fix == "" || strings.Contains(fix, "\n") {
+		return "", false
+	}
+	return fix, true
+}
+
+// findExistingReview returns this analyzer's previous review on the PR, if
+// any, identified by prReviewMarker in its body.
+func findExistingReview(ctx context.Context, client *github.Client, owner, repo string, number int) (*github.PullRequestReview, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range reviews {
+			if strings.Contains(r.GetBody(), prReviewMarker) {
+				return r, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return nil, nil
+}
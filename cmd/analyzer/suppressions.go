@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/suppress"
+)
+
+// applyInlineSuppressions parses path's // nolint:apiperf and
+// // apiperf:ignore <ruleId> comments and marks the findings they cover as
+// Suppressed. A suppression that never matched anything is reported back as
+// its own low-severity finding, so suppressions don't silently rot once the
+// issue they were written for is fixed or moves.
+func applyInlineSuppressions(path string, content []byte, result *analysis.CodeAnalysis) {
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, path, content, parser.ParseComments)
+	if err != nil {
+		return // syntax errors are already reported elsewhere; nothing to suppress
+	}
+
+	suppressions := suppress.Parse(fset, node)
+	if len(suppressions) == 0 {
+		return
+	}
+
+	for i := range result.PerformanceHints {
+		hint := &result.PerformanceHints[i]
+		markMatching(suppressions, hint.LineNumber, ruleIDForHint(*hint), &hint.Suppressed)
+	}
+	for i := range result.SecurityIssues {
+		issue := &result.SecurityIssues[i]
+		markMatching(suppressions, issue.LineNumber, ruleIDForIssue(*issue), &issue.Suppressed)
+	}
+
+	for _, s := range suppressions {
+		if s.Used {
+			continue
+		}
+		result.SecurityIssues = append(result.SecurityIssues, analysis.SecurityIssue{
+			Type:        "unused_suppression",
+			Description: fmt.Sprintf("suppression comment on line %d never matched a finding (rule=%q)", s.Line, s.RuleID),
+			Severity:    "low",
+			LineNumber:  s.Line,
+			Suggestion:  "remove the stale suppression comment, or check the rule id is spelled correctly",
+		})
+	}
+}
+
+func markMatching(suppressions []suppress.Suppression, line int, ruleID string, suppressed *bool) {
+	for i := range suppressions {
+		if suppressions[i].Matches(line, ruleID) {
+			*suppressed = true
+			suppressions[i].Used = true
+		}
+	}
+}
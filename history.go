@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/store"
+
+	"github.com/gin-gonic/gin"
+)
+
+// historyStore persists every /api/v1/analyze result so getStats,
+// getHistory, getTrends, and getTopRules have real data instead of a
+// hardcoded zero. Set up in main() from STORE_DSN; nil (and therefore a
+// no-op Save, and a 503 from the read endpoints) if it failed to open.
+var historyStore store.Store
+
+// openHistoryStore opens the Store STORE_DSN selects: a "postgres://" or
+// "postgresql://" DSN opens Postgres, anything else (including unset, which
+// defaults to "analyzer.db") opens the zero-config SQLite backend. A
+// failure to open is reported but non-fatal: a broken history database
+// shouldn't stop the server from analyzing code.
+func openHistoryStore() store.Store {
+	s, err := store.Open(os.Getenv("STORE_DSN"), store.PoolConfig{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  history disabled: %v\n", err)
+		return nil
+	}
+	return s
+}
+
+// recordHistory saves result under the server's current git SHA, best
+// effort and off the request path: a request that triggered the analysis
+// shouldn't wait on (or fail because of) a slow or unavailable history
+// store.
+func recordHistory(result analysis.CodeAnalysis) {
+	if historyStore == nil {
+		return
+	}
+	go func() {
+		if err := historyStore.Save(context.Background(), result, gitSHA()); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  failed to record history: %v\n", err)
+		}
+	}()
+}
+
+// gitSHA returns the commit the running server was built from: GITHUB_SHA
+// when set (GitHub Actions), otherwise `git rev-parse HEAD` in the working
+// directory, falling back to "unknown" outside a git checkout.
+func gitSHA() string {
+	if sha := os.Getenv("GITHUB_SHA"); sha != "" {
+		return sha
+	}
+
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "unknown"
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// getHistory handles GET /api/v1/history?since=<RFC3339>&language=<name>:
+// every analysis recorded since since (default the last 30 days), optionally
+// restricted to one language.
+func getHistory(c *gin.Context) {
+	if historyStore == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "history store not configured"})
+		return
+	}
+
+	since := time.Now().AddDate(0, 0, -30)
+	if raw := c.Query("since"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since: " + err.Error()})
+			return
+		}
+		since = parsed
+	}
+
+	history, err := historyStore.History(c.Request.Context(), since, c.Query("language"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"since": since, "history": history})
+}
+
+// getTrends handles GET /api/v1/trends?since=<RFC3339>&bucket=day|week:
+// the average PerformanceScore time series since since (default the last
+// 90 days), bucketed by day or week.
+func getTrends(c *gin.Context) {
+	if historyStore == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "history store not configured"})
+		return
+	}
+
+	since := time.Now().AddDate(0, -3, 0)
+	if raw := c.Query("since"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since: " + err.Error()})
+			return
+		}
+		since = parsed
+	}
+
+	trend, err := historyStore.ScoreTrend(c.Request.Context(), since, c.Query("bucket"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"since": since, "trend": trend})
+}
+
+// getTopRules handles GET /api/v1/rules/top?limit=N: the N most frequently
+// triggered SecurityIssue.Type/PerformanceHint.Issue across every recorded
+// analysis, N defaulting to 10.
+func getTopRules(c *gin.Context) {
+	if historyStore == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "history store not configured"})
+		return
+	}
+
+	limit := 10
+	if raw := c.Query("limit"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			limit = n
+		}
+	}
+
+	rules, err := historyStore.TopIssues(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"rules": rules})
+}
@@ -1,11 +1,14 @@
 package analysis
 
 import (
+	"context"
+	"fmt"
 	"go/ast"
-	"go/parser"
 	"go/token"
 	"strings"
 	"time"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/dbexplain"
 )
 
 // CodeAnalysis represents the complete analysis results
@@ -27,6 +30,23 @@ type SecurityIssue struct {
 	Severity    string `json:"severity"`
 	LineNumber  int    `json:"line_number"`
 	Suggestion  string `json:"suggestion"`
+	// DataFlow holds the source-to-sink propagation path for issues raised
+	// by the taint analyzer (see internal/analysis/taint). It is empty for
+	// issues raised by the substring/AST heuristics, which have no path to
+	// report.
+	DataFlow []Step `json:"data_flow,omitempty"`
+	// Suppressed marks a pre-existing issue recorded in the baseline file,
+	// or silenced by an inline apiperf:ignore/nolint comment. Suppressed
+	// issues are excluded from fail-the-build decisions but still rendered.
+	Suppressed bool `json:"suppressed,omitempty"`
+}
+
+// Step is one hop in a tainted value's path from source to sink, e.g. for
+// rendering as a SARIF codeFlow or an indented Markdown trace.
+type Step struct {
+	Description string `json:"description"`
+	FilePath    string `json:"file_path"`
+	LineNumber  int    `json:"line_number"`
 }
 
 type PerformanceHint struct {
@@ -36,6 +56,9 @@ type PerformanceHint struct {
 	CodeExample string `json:"code_example"`
 	LineNumber  int    `json:"line_number"`
 	Severity    string `json:"severity"`
+	// Suppressed marks a pre-existing hint recorded in the baseline file,
+	// or silenced by an inline apiperf:ignore/nolint comment.
+	Suppressed bool `json:"suppressed,omitempty"`
 }
 
 type BestPractice struct {
@@ -58,42 +81,37 @@ type FileAnalysis struct {
 	Analysis CodeAnalysis `json:"analysis"`
 }
 
-// AnalyzeCode performs comprehensive analysis on Go code
+// AnalyzeCode performs comprehensive analysis on Go code. It is a sync
+// adapter over AnalyzeCodeStreaming: callers that want incremental results
+// as they're produced (e.g. an SSE handler) should call that directly
+// instead. ctx can never cancel context.Background(), so the error
+// AnalyzeCodeContext can return is always nil here.
 func AnalyzeCode(code, codeType, filePath string) CodeAnalysis {
-	analysis := CodeAnalysis{
-		AnalysisTime:      time.Now(),
-		SecurityIssues:    []SecurityIssue{},
-		PerformanceHints:  []PerformanceHint{},
-		BestPractices:     []BestPractice{},
-		AIRecommendations: []AIRecommendation{},
-		FilePath:          filePath,
-	}
+	result, _ := AnalyzeCodeContext(context.Background(), code, codeType, filePath)
+	return result
+}
 
-	// Parse Go code
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, filePath, code, parser.ParseComments)
-	if err != nil {
-		// If parsing fails, return basic analysis
-		analysis.SecurityIssues = append(analysis.SecurityIssues, SecurityIssue{
-			Type:        "syntax_error",
-			Description: "Code contains syntax errors",
-			Severity:    "high",
-			Suggestion:  "Fix syntax errors before analysis: " + err.Error(),
-		})
-		return analysis
+// AnalyzeCodeContext is AnalyzeCode, but honors ctx: a caller enforcing a
+// per-request deadline (e.g. an HTTP handler wrapping the request context in
+// context.WithTimeout) gets a non-nil error instead of a zero-value
+// CodeAnalysis if ctx is cancelled before the analysis finishes, so it can
+// tell a timed-out request apart from a real, clean result.
+func AnalyzeCodeContext(ctx context.Context, code, codeType, filePath string) (CodeAnalysis, error) {
+	events := make(chan AnalysisEvent)
+	go AnalyzeCodeStreaming(ctx, code, codeType, filePath, events)
+
+	var result CodeAnalysis
+	var got bool
+	for evt := range events {
+		if evt.Type == EventTypeSummary {
+			result = *evt.Summary
+			got = true
+		}
 	}
-
-	// Analyze for common issues
-	analysis.SecurityIssues = detectSecurityIssues(node, fset, code)
-	analysis.PerformanceHints = detectPerformanceIssues(node, fset, code)
-	analysis.BestPractices = suggestBestPractices(node, fset, code)
-	analysis.AIRecommendations = generateAIRecommendations(code, codeType)
-	analysis.CodeComplexity = calculateComplexity(node)
-
-	// Calculate performance score
-	analysis.PerformanceScore, analysis.PerformanceGrade = calculatePerformanceScore(analysis.PerformanceHints)
-
-	return analysis
+	if !got {
+		return CodeAnalysis{}, fmt.Errorf("analysis did not complete: %w", ctx.Err())
+	}
+	return result, nil
 }
 
 func detectSecurityIssues(node *ast.File, fset *token.FileSet, code string) []SecurityIssue {
@@ -284,8 +302,60 @@ db.Preload("Items").Find(&orders)`,
 	return hints
 }
 
+// dbExplainer, when set via SetDBExplainer, replaces detectMissingIndexes's
+// substring heuristic with a real EXPLAIN against a live database (see
+// internal/analysis/dbexplain). Nil (the default) means no DSN was
+// configured, so the heuristic below is used instead.
+var dbExplainer *dbexplain.Explainer
+
+// SetDBExplainer configures detectMissingIndexes to run real EXPLAINs
+// against e instead of matching WHERE-clause substrings. Pass nil to
+// restore the heuristic.
+func SetDBExplainer(e *dbexplain.Explainer) {
+	dbExplainer = e
+}
+
 // Detect queries that would benefit from database indexes
 func detectMissingIndexes(node *ast.File, fset *token.FileSet, code string) []PerformanceHint {
+	if dbExplainer != nil {
+		return detectMissingIndexesViaExplain(node, fset)
+	}
+	return detectMissingIndexesHeuristic(code)
+}
+
+// detectMissingIndexesViaExplain extracts SQL literals from db.Query/Exec/
+// .Raw/.Where call sites and runs a real EXPLAIN against dbExplainer's
+// database, only reporting a hint when the plan shows a sequential scan
+// over a large estimated row count.
+func detectMissingIndexesViaExplain(node *ast.File, fset *token.FileSet) []PerformanceHint {
+	var hints []PerformanceHint
+
+	for _, q := range dbexplain.ExtractQueries(node, fset) {
+		plan, err := dbExplainer.Explain(context.Background(), q.SQL)
+		if err != nil || !plan.SeqScan || plan.EstimatedRows < dbexplain.SeqScanRowThreshold {
+			continue
+		}
+
+		hints = append(hints, PerformanceHint{
+			Issue:      "Missing Database Index",
+			Impact:     fmt.Sprintf("🟠 HIGH: EXPLAIN shows a sequential scan over ~%d estimated rows", plan.EstimatedRows),
+			Solution:   "Add a database index on the column(s) this query filters or joins on",
+			Severity:   "high",
+			LineNumber: q.LineNumber,
+			CodeExample: fmt.Sprintf(`-- EXPLAIN reported a Seq Scan for:
+-- %s
+CREATE INDEX ON <table> (<column>);`, q.SQL),
+		})
+	}
+
+	return hints
+}
+
+// detectMissingIndexesHeuristic is the original substring-matching
+// detector, used when no -explain-dsn is configured (see cmd/analyzer). It
+// greps for WHERE clauses on columns that commonly lack an index, which can
+// produce false positives on tables that already have one.
+func detectMissingIndexesHeuristic(code string) []PerformanceHint {
 	var hints []PerformanceHint
 
 	// Look for WHERE clauses on potentially unindexed columns
@@ -550,6 +620,13 @@ func calculateComplexity(node *ast.File) int {
 	return complexity
 }
 
+// ScorePerformance exposes calculatePerformanceScore to other language
+// analyzers (see internal/analysis/langs), so they grade their findings the
+// same way the Go analyzer does instead of inventing their own scale.
+func ScorePerformance(hints []PerformanceHint) (int, string) {
+	return calculatePerformanceScore(hints)
+}
+
 // Calculate performance score based on detected issues
 func calculatePerformanceScore(hints []PerformanceHint) (int, string) {
 	score := 100
@@ -571,21 +648,26 @@ func calculatePerformanceScore(hints []PerformanceHint) (int, string) {
 		score = 0
 	}
 
-	var grade string
+	return score, GradeFromScore(score)
+}
+
+// GradeFromScore maps a 0-100 performance score to a letter grade, the same
+// scale calculatePerformanceScore uses, so anything deriving its own score
+// (e.g. internal/analysis/batch's repo-level weighted average) grades it
+// consistently.
+func GradeFromScore(score int) string {
 	switch {
 	case score >= 95:
-		grade = "A+"
+		return "A+"
 	case score >= 90:
-		grade = "A"
+		return "A"
 	case score >= 80:
-		grade = "B"
+		return "B"
 	case score >= 70:
-		grade = "C"
+		return "C"
 	case score >= 60:
-		grade = "D"
+		return "D"
 	default:
-		grade = "F"
+		return "F"
 	}
-
-	return score, grade
 }
@@ -0,0 +1,154 @@
+// Package archive uploads completed analyses to an S3-compatible object
+// store (AWS S3, MinIO, ...) under <bucket>/<repo>/<git-sha>/<file-path>,
+// so historical reports can be retrieved without standing up a database.
+// Configuration is read from environment variables (see ConfigFromEnv).
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// Config configures an Archive.
+type Config struct {
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	UseSSL    bool
+}
+
+// ConfigFromEnv reads Config from ARCHIVE_ENDPOINT, ARCHIVE_ACCESS_KEY,
+// ARCHIVE_SECRET_KEY, ARCHIVE_BUCKET, and ARCHIVE_USE_SSL (default true).
+// ok is false when ARCHIVE_ENDPOINT or ARCHIVE_BUCKET isn't set, meaning
+// archival isn't configured.
+func ConfigFromEnv() (cfg Config, ok bool) {
+	cfg = Config{
+		Endpoint:  os.Getenv("ARCHIVE_ENDPOINT"),
+		AccessKey: os.Getenv("ARCHIVE_ACCESS_KEY"),
+		SecretKey: os.Getenv("ARCHIVE_SECRET_KEY"),
+		Bucket:    os.Getenv("ARCHIVE_BUCKET"),
+		UseSSL:    true,
+	}
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return Config{}, false
+	}
+	if v := os.Getenv("ARCHIVE_USE_SSL"); v != "" {
+		if useSSL, err := strconv.ParseBool(v); err == nil {
+			cfg.UseSSL = useSSL
+		}
+	}
+	return cfg, true
+}
+
+// Archive uploads and retrieves archived analyses in an S3-compatible
+// bucket.
+type Archive struct {
+	client *minio.Client
+	bucket string
+}
+
+// New connects to cfg.Endpoint and creates cfg.Bucket if it doesn't already
+// exist.
+func New(ctx context.Context, cfg Config) (*Archive, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("connect to %s: %w", cfg.Endpoint, err)
+	}
+
+	exists, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("check bucket %s: %w", cfg.Bucket, err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{}); err != nil {
+			return nil, fmt.Errorf("create bucket %s: %w", cfg.Bucket, err)
+		}
+	}
+
+	return &Archive{client: client, bucket: cfg.Bucket}, nil
+}
+
+// Key returns the object key prefix (without extension) an analysis for
+// path is stored under: <repo>/<gitSHA>/<path>.
+func Key(repo, gitSHA, path string) string {
+	return fmt.Sprintf("%s/%s/%s", repo, gitSHA, path)
+}
+
+// Upload stores result's JSON and a rendered HTML report under
+// <repo>/<gitSHA>/<result.FilePath>.{json,html}, server-side encrypted.
+func (a *Archive) Upload(ctx context.Context, repo, gitSHA string, result analysis.FileAnalysis) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal result: %w", err)
+	}
+	if err := a.put(ctx, Key(repo, gitSHA, result.FilePath)+".json", "application/json", data); err != nil {
+		return err
+	}
+
+	html := []byte(renderHTML(result))
+	return a.put(ctx, Key(repo, gitSHA, result.FilePath)+".html", "text/html", html)
+}
+
+func (a *Archive) put(ctx context.Context, key, contentType string, data []byte) error {
+	_, err := a.client.PutObject(ctx, a.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType:          contentType,
+		ServerSideEncryption: encrypt.NewSSE(),
+	})
+	if err != nil {
+		return fmt.Errorf("upload %s: %w", key, err)
+	}
+	return nil
+}
+
+// List returns every git SHA archived for repo, newest-listed-last order
+// isn't guaranteed since S3 keys carry no timestamp ordering; callers that
+// need "since sinceSHA" should cross-reference against their own commit
+// history.
+func (a *Archive) List(ctx context.Context, repo string) ([]string, error) {
+	seen := make(map[string]bool)
+	var shas []string
+
+	prefix := repo + "/"
+	for obj := range a.client.ListObjects(ctx, a.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return nil, obj.Err
+		}
+		rest := strings.TrimPrefix(obj.Key, prefix)
+		sha, _, ok := strings.Cut(rest, "/")
+		if !ok || seen[sha] {
+			continue
+		}
+		seen[sha] = true
+		shas = append(shas, sha)
+	}
+	return shas, nil
+}
+
+// Fetch downloads the archived analysis.FileAnalysis for repo/sha/path.
+func (a *Archive) Fetch(ctx context.Context, repo, sha, path string) (analysis.FileAnalysis, error) {
+	obj, err := a.client.GetObject(ctx, a.bucket, Key(repo, sha, path)+".json", minio.GetObjectOptions{})
+	if err != nil {
+		return analysis.FileAnalysis{}, fmt.Errorf("fetch %s: %w", path, err)
+	}
+	defer obj.Close()
+
+	var fa analysis.FileAnalysis
+	if err := json.NewDecoder(obj).Decode(&fa); err != nil {
+		return analysis.FileAnalysis{}, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return fa, nil
+}
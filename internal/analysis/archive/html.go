@@ -0,0 +1,42 @@
+package archive
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+)
+
+// reportTemplate renders a FileAnalysis as a standalone HTML report, for
+// archived results to be viewed directly from the bucket without the CLI.
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Analysis report: {{.FilePath}}</title>
+</head>
+<body>
+<h1>{{.FilePath}}</h1>
+<p>Grade: {{.Analysis.PerformanceGrade}} (score {{.Analysis.PerformanceScore}}, complexity {{.Analysis.CodeComplexity}})</p>
+
+<h2>Security issues ({{len .Analysis.SecurityIssues}})</h2>
+<ul>
+{{range .Analysis.SecurityIssues}}<li>[{{.Severity}}] line {{.LineNumber}}: {{.Description}} &mdash; {{.Suggestion}}</li>
+{{end}}</ul>
+
+<h2>Performance hints ({{len .Analysis.PerformanceHints}})</h2>
+<ul>
+{{range .Analysis.PerformanceHints}}<li>[{{.Severity}}] line {{.LineNumber}}: {{.Issue}} &mdash; {{.Solution}}</li>
+{{end}}</ul>
+</body>
+</html>
+`))
+
+// renderHTML renders result as a standalone HTML report.
+func renderHTML(result analysis.FileAnalysis) string {
+	var buf bytes.Buffer
+	if err := reportTemplate.Execute(&buf, result); err != nil {
+		return ""
+	}
+	return buf.String()
+}
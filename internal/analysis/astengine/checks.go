@@ -0,0 +1,165 @@
+package astengine
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+)
+
+func init() {
+	Register(n1QueryChecker{})
+	Register(sqlSprintfChecker{})
+	Register(hardcodedSecretChecker{})
+}
+
+// dbMethods lists GORM/database-ish method names treated as a database call
+// for the purposes of N+1 detection. Kept in sync with the substring-based
+// isDBCall in the legacy analyzer so the two detectors agree while both
+// exist side by side.
+var dbMethods = map[string]bool{
+	"Find": true, "First": true, "Last": true, "Take": true, "Where": true,
+	"Select": true, "Order": true, "Limit": true, "Offset": true,
+	"Create": true, "Save": true, "Update": true, "UpdateColumn": true, "UpdateColumns": true, "Updates": true,
+	"Delete": true, "Unscoped": true, "Raw": true, "Exec": true, "Scan": true, "Rows": true, "Row": true,
+	"Count": true, "Group": true, "Having": true, "Joins": true, "Preload": true, "Related": true, "Association": true,
+}
+
+func isDBCall(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && dbMethods[sel.Sel.Name]
+}
+
+// n1QueryChecker flags database calls made inside a for/range loop body,
+// which typically execute once per iteration instead of once total.
+type n1QueryChecker struct{}
+
+func (n1QueryChecker) Name() string { return "n1query" }
+
+func (n1QueryChecker) Check(pass *Pass) []Finding {
+	var findings []Finding
+
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			var body ast.Node
+			switch stmt := n.(type) {
+			case *ast.ForStmt:
+				body = stmt.Body
+			case *ast.RangeStmt:
+				body = stmt.Body
+			default:
+				return true
+			}
+
+			ast.Inspect(body, func(inner ast.Node) bool {
+				call, ok := inner.(*ast.CallExpr)
+				if !ok || !isDBCall(call) {
+					return true
+				}
+				findings = append(findings, Finding{
+					CheckerName: "n1query",
+					Message:     "database call inside a loop body executes once per iteration instead of once total",
+					Severity:    "critical",
+					Pos:         pass.Fset.Position(call.Pos()),
+				})
+				return false
+			})
+			return true
+		})
+	}
+
+	return findings
+}
+
+// sqlSprintfChecker flags fmt.Sprintf/string concatenation results passed
+// directly into db.Raw/db.Query/db.Exec, the classic SQL injection shape.
+type sqlSprintfChecker struct{}
+
+func (sqlSprintfChecker) Name() string { return "sql-sprintf" }
+
+var rawQueryMethods = map[string]bool{"Raw": true, "Query": true, "Exec": true}
+
+func (sqlSprintfChecker) Check(pass *Pass) []Finding {
+	var findings []Finding
+
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || !rawQueryMethods[sel.Sel.Name] || len(call.Args) == 0 {
+				return true
+			}
+
+			if isBuiltFromUntrustedConcat(call.Args[0]) {
+				findings = append(findings, Finding{
+					CheckerName: "sql-sprintf",
+					Message:     "SQL query built with string concatenation/Sprintf passed to " + sel.Sel.Name + " - use parameterized placeholders instead",
+					Severity:    "high",
+					Pos:         pass.Fset.Position(call.Args[0].Pos()),
+				})
+			}
+			return true
+		})
+	}
+
+	return findings
+}
+
+// isBuiltFromUntrustedConcat reports whether expr is a fmt.Sprintf call or a
+// binary "+" string concatenation, as opposed to a string literal or a
+// variable holding a pre-built parameterized query.
+func isBuiltFromUntrustedConcat(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		pkgIdent, ok := sel.X.(*ast.Ident)
+		return ok && pkgIdent.Name == "fmt" && sel.Sel.Name == "Sprintf"
+	case *ast.BinaryExpr:
+		return e.Op == token.ADD
+	}
+	return false
+}
+
+// hardcodedSecretChecker flags untyped string constants/vars whose name
+// looks like a credential, e.g. `password = "hunter2"`.
+type hardcodedSecretChecker struct{}
+
+func (hardcodedSecretChecker) Name() string { return "hardcoded-secret" }
+
+var secretNameRE = regexp.MustCompile(`(?i)(password|secret|api[_-]?key|token)`)
+
+func (hardcodedSecretChecker) Check(pass *Pass) []Finding {
+	var findings []Finding
+
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			valueSpec, ok := n.(*ast.ValueSpec)
+			if !ok {
+				return true
+			}
+			for i, name := range valueSpec.Names {
+				if i >= len(valueSpec.Values) || !secretNameRE.MatchString(name.Name) {
+					continue
+				}
+				lit, ok := valueSpec.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				findings = append(findings, Finding{
+					CheckerName: "hardcoded-secret",
+					Message:     "possible hardcoded credential assigned to `" + name.Name + "`",
+					Severity:    "high",
+					Pos:         pass.Fset.Position(lit.Pos()),
+				})
+			}
+			return true
+		})
+	}
+
+	return findings
+}
@@ -0,0 +1,208 @@
+// Package batch analyzes a set of in-memory files concurrently through a
+// bounded worker pool and aggregates the results into a repo-level report:
+// cross-file duplicated-issue clustering, a weighted performance grade, and
+// the top hotspot files. Unlike internal/analysis/crawler (which walks a
+// directory tree on disk), batch takes its files already in memory, so it
+// fits a request handler that received them as a zip upload or a JSON list.
+package batch
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/langs"
+)
+
+// File is one file to analyze: its repo-relative path and source content.
+type File struct {
+	Path string
+	Code []byte
+}
+
+// Location is one occurrence of a clustered issue.
+type Location struct {
+	FilePath   string `json:"file_path"`
+	LineNumber int    `json:"line_number"`
+}
+
+// Cluster groups every occurrence of the same SecurityIssue.Type across the
+// batch, so a problem repeated in many files shows up once with its full
+// location list instead of being lost among per-file results.
+type Cluster struct {
+	Type      string     `json:"type"`
+	Count     int        `json:"count"`
+	Locations []Location `json:"locations"`
+}
+
+// Hotspot is one file ranked by its count of critical findings: critical- or
+// high-severity security issues plus critical-severity performance hints.
+type Hotspot struct {
+	FilePath         string `json:"file_path"`
+	CriticalFindings int    `json:"critical_findings"`
+	PerformanceScore int    `json:"performance_score"`
+}
+
+// Report is the aggregate output of a Run.
+type Report struct {
+	Files         []analysis.FileAnalysis `json:"files"`
+	Clusters      []Cluster               `json:"clusters"`
+	WeightedGrade string                  `json:"weighted_grade"`
+	WeightedScore float64                 `json:"weighted_score"`
+	Hotspots      []Hotspot               `json:"hotspots"`
+}
+
+// Options configures Run.
+type Options struct {
+	// Workers is the worker pool size; <= 0 means runtime.GOMAXPROCS(0).
+	Workers int
+	// OnFile is called after each file finishes analysis, for progress
+	// reporting (e.g. updating a Job's Completed count). May be nil.
+	OnFile func(path string)
+}
+
+// analyzeFile dispatches path to the LanguageAnalyzer registered for its
+// extension, falling back to the native Go heuristics for unregistered
+// extensions, matching analyzeByType's fallback in the HTTP handlers. ok is
+// false if ctx was cancelled before the Go path finished, in which case f
+// should be dropped rather than reported with a zero-value result.
+func analyzeFile(ctx context.Context, f File) (analysis.FileAnalysis, bool) {
+	if lang, registered := langs.ForPath(f.Path); registered {
+		if r, err := lang.Analyze(ctx, f.Path, f.Code); err == nil {
+			return analysis.FileAnalysis{FilePath: f.Path, Analysis: r}, true
+		}
+	}
+	r, err := analysis.AnalyzeCodeContext(ctx, string(f.Code), "", f.Path)
+	if err != nil {
+		return analysis.FileAnalysis{}, false
+	}
+	return analysis.FileAnalysis{FilePath: f.Path, Analysis: r}, true
+}
+
+// Run fans files out across a worker pool and returns the aggregated
+// Report. It respects ctx cancellation: in-flight workers finish their
+// current file, but no new file is dispatched once ctx is done.
+func Run(ctx context.Context, files []File, opts Options) Report {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	jobs := make(chan File)
+	resultsCh := make(chan analysis.FileAnalysis, len(files))
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range jobs {
+				if fa, ok := analyzeFile(ctx, f); ok {
+					resultsCh <- fa
+				}
+				if opts.OnFile != nil {
+					opts.OnFile(f.Path)
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, f := range files {
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- f:
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	var results []analysis.FileAnalysis
+	for fa := range resultsCh {
+		results = append(results, fa)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].FilePath < results[j].FilePath })
+
+	return aggregate(results)
+}
+
+// aggregate derives a Report's clusters, weighted grade, and hotspots from
+// Run's completed files.
+func aggregate(files []analysis.FileAnalysis) Report {
+	report := Report{Files: files}
+	if len(files) == 0 {
+		return report
+	}
+
+	clusters := make(map[string]*Cluster)
+	var totalScore, totalLines int
+
+	for _, fa := range files {
+		weight := lineWeight(fa)
+		totalScore += fa.Analysis.PerformanceScore * weight
+		totalLines += weight
+
+		critical := 0
+		for _, issue := range fa.Analysis.SecurityIssues {
+			c, ok := clusters[issue.Type]
+			if !ok {
+				c = &Cluster{Type: issue.Type}
+				clusters[issue.Type] = c
+			}
+			c.Count++
+			c.Locations = append(c.Locations, Location{FilePath: fa.FilePath, LineNumber: issue.LineNumber})
+			if issue.Severity == "critical" || issue.Severity == "high" {
+				critical++
+			}
+		}
+		for _, hint := range fa.Analysis.PerformanceHints {
+			if hint.Severity == "critical" {
+				critical++
+			}
+		}
+		if critical > 0 {
+			report.Hotspots = append(report.Hotspots, Hotspot{
+				FilePath:         fa.FilePath,
+				CriticalFindings: critical,
+				PerformanceScore: fa.Analysis.PerformanceScore,
+			})
+		}
+	}
+
+	for _, c := range clusters {
+		report.Clusters = append(report.Clusters, *c)
+	}
+	sort.Slice(report.Clusters, func(i, j int) bool { return report.Clusters[i].Count > report.Clusters[j].Count })
+
+	sort.Slice(report.Hotspots, func(i, j int) bool {
+		return report.Hotspots[i].CriticalFindings > report.Hotspots[j].CriticalFindings
+	})
+	if len(report.Hotspots) > 10 {
+		report.Hotspots = report.Hotspots[:10]
+	}
+
+	if totalLines > 0 {
+		report.WeightedScore = float64(totalScore) / float64(totalLines)
+	}
+	report.WeightedGrade = analysis.GradeFromScore(int(report.WeightedScore))
+
+	return report
+}
+
+// lineWeight weights a file's contribution to the repo-level score by its
+// complexity, so a large, complex file isn't drowned out by many trivial
+// ones. A minimum of 1 keeps empty-complexity files from vanishing.
+func lineWeight(fa analysis.FileAnalysis) int {
+	if fa.Analysis.CodeComplexity <= 0 {
+		return 1
+	}
+	return fa.Analysis.CodeComplexity
+}
@@ -0,0 +1,111 @@
+package batch
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+)
+
+// Status is a Job's lifecycle state.
+type Status string
+
+const (
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// Job tracks one asynchronous Run, for a client to poll
+// GET /api/v1/analyze/batch/{jobID}/status instead of holding a connection
+// open for however long a large repository takes to analyze. Fields other
+// than ID and Total are mutated by the background goroutine started in
+// Manager.Start and must only be read through Manager.Snapshot.
+type Job struct {
+	ID        string
+	Total     int
+	completed int64
+	status    Status
+	report    *Report
+	err       string
+}
+
+// JobView is a point-in-time, race-free copy of a Job's state, safe to
+// serialize directly as a status response.
+type JobView struct {
+	ID        string  `json:"id"`
+	Status    Status  `json:"status"`
+	Total     int     `json:"total"`
+	Completed int     `json:"completed"`
+	Report    *Report `json:"report,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Manager tracks in-flight and completed Jobs in memory.
+type Manager struct {
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// NewManager returns an empty Manager.
+func NewManager() *Manager {
+	return &Manager{jobs: make(map[string]*Job)}
+}
+
+// Start runs files through Run in the background under a new Job and
+// returns its ID immediately; poll Snapshot(id) for progress and, once
+// Status is StatusDone, the final Report.
+func (m *Manager) Start(ctx context.Context, files []File, workers int) string {
+	job := &Job{ID: newJobID(), Total: len(files), status: StatusRunning}
+
+	m.mu.Lock()
+	m.jobs[job.ID] = job
+	m.mu.Unlock()
+
+	go func() {
+		report := Run(ctx, files, Options{
+			Workers: workers,
+			OnFile:  func(string) { atomic.AddInt64(&job.completed, 1) },
+		})
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if ctx.Err() != nil {
+			job.status = StatusFailed
+			job.err = ctx.Err().Error()
+		} else {
+			job.status = StatusDone
+		}
+		job.report = &report
+	}()
+
+	return job.ID
+}
+
+// Snapshot returns a race-free copy of the job with id, if any.
+func (m *Manager) Snapshot(id string) (JobView, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return JobView{}, false
+	}
+	return JobView{
+		ID:        job.ID,
+		Status:    job.status,
+		Total:     job.Total,
+		Completed: int(atomic.LoadInt64(&job.completed)),
+		Report:    job.report,
+		Error:     job.err,
+	}, true
+}
+
+func newJobID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "job-fallback"
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,202 @@
+// Package crawler walks a repository tree concurrently, analyzing every
+// eligible file through a worker pool, respecting the tree's .gitignore,
+// and aggregating the results into a repo-level summary of hotspot files.
+// Scans are interruptible: cancelling ctx stops dispatching new files and
+// Crawl returns whatever results the in-flight workers already produced.
+package crawler
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+)
+
+// AnalyzeFunc analyzes one file's content and returns its result.
+type AnalyzeFunc func(path string, content []byte) (analysis.CodeAnalysis, error)
+
+// Options configures Crawl.
+type Options struct {
+	// Workers is the worker pool size; <= 0 means runtime.GOMAXPROCS(0).
+	Workers int
+	// IsEligible reports whether path should be analyzed, after .gitignore
+	// filtering has already excluded it. Nil means every non-ignored file
+	// is analyzed.
+	IsEligible func(path string) bool
+	// OnFile is called after each file finishes analysis, for progress
+	// reporting. May be nil.
+	OnFile func(path string)
+}
+
+// Hotspot is one file ranked by its count of critical PerformanceHints, used
+// to surface the riskiest files in a large tree first.
+type Hotspot struct {
+	FilePath         string `json:"file_path"`
+	CriticalHints    int    `json:"critical_hints"`
+	PerformanceScore int    `json:"performance_score"`
+}
+
+// Summary aggregates a Crawl's results across the whole tree.
+type Summary struct {
+	FilesAnalyzed   int       `json:"files_analyzed"`
+	TotalComplexity int       `json:"total_complexity"`
+	AverageScore    float64   `json:"average_score"`
+	Hotspots        []Hotspot `json:"hotspots"`
+}
+
+// Result is the output of a Crawl: every file's analysis plus the
+// repo-level Summary derived from them.
+type Result struct {
+	Files   []analysis.FileAnalysis `json:"files"`
+	Summary Summary                 `json:"summary"`
+}
+
+// Paths returns every non-ignored, eligible file under root, for sizing a
+// progress bar before Crawl walks the tree again to analyze them.
+func Paths(root string, isEligible func(path string) bool) ([]string, error) {
+	ignore, err := loadGitignore(root)
+	if err != nil {
+		return nil, err
+	}
+	return collectPaths(root, ignore, isEligible)
+}
+
+func collectPaths(root string, ignore *ignoreSet, isEligible func(string) bool) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil {
+			rel = path
+		}
+		rel = filepath.ToSlash(rel)
+
+		if ignore.match(rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if isEligible != nil && !isEligible(path) {
+			return nil
+		}
+
+		paths = append(paths, path)
+		return nil
+	})
+	return paths, err
+}
+
+// Crawl walks root, fans the eligible files out to a worker pool, and runs
+// analyze on each.
+func Crawl(ctx context.Context, root string, analyze AnalyzeFunc, opts Options) (Result, error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	ignore, err := loadGitignore(root)
+	if err != nil {
+		return Result{}, err
+	}
+
+	paths, err := collectPaths(root, ignore, opts.IsEligible)
+	if err != nil {
+		return Result{}, err
+	}
+
+	jobs := make(chan string)
+	resultsCh := make(chan analysis.FileAnalysis, len(paths))
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range jobs {
+				content, readErr := os.ReadFile(path)
+				if readErr != nil {
+					continue
+				}
+				result, analyzeErr := analyze(path, content)
+				if analyzeErr != nil {
+					continue
+				}
+				resultsCh <- analysis.FileAnalysis{FilePath: path, Analysis: result}
+				if opts.OnFile != nil {
+					opts.OnFile(path)
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, path := range paths {
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- path:
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	var files []analysis.FileAnalysis
+	for fa := range resultsCh {
+		files = append(files, fa)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].FilePath < files[j].FilePath })
+
+	return Result{Files: files, Summary: summarize(files)}, nil
+}
+
+// summarize derives Summary from a Crawl's completed files.
+func summarize(files []analysis.FileAnalysis) Summary {
+	summary := Summary{FilesAnalyzed: len(files)}
+	if len(files) == 0 {
+		return summary
+	}
+
+	var totalScore int
+	for _, fa := range files {
+		summary.TotalComplexity += fa.Analysis.CodeComplexity
+		totalScore += fa.Analysis.PerformanceScore
+
+		critical := 0
+		for _, hint := range fa.Analysis.PerformanceHints {
+			if hint.Severity == "critical" {
+				critical++
+			}
+		}
+		if critical > 0 {
+			summary.Hotspots = append(summary.Hotspots, Hotspot{
+				FilePath:         fa.FilePath,
+				CriticalHints:    critical,
+				PerformanceScore: fa.Analysis.PerformanceScore,
+			})
+		}
+	}
+	summary.AverageScore = float64(totalScore) / float64(len(files))
+
+	sort.Slice(summary.Hotspots, func(i, j int) bool {
+		return summary.Hotspots[i].CriticalHints > summary.Hotspots[j].CriticalHints
+	})
+
+	return summary
+}
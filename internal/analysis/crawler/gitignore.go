@@ -0,0 +1,57 @@
+package crawler
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ignoreSet holds patterns loaded from root's top-level .gitignore, good
+// enough for the common cases (*.ext, dir/, comments, blank lines) without
+// pulling in a full git implementation. .git/ is always ignored.
+type ignoreSet struct {
+	patterns []string
+}
+
+// loadGitignore reads root/.gitignore, if present.
+func loadGitignore(root string) (*ignoreSet, error) {
+	set := &ignoreSet{patterns: []string{".git/"}}
+
+	data, err := os.ReadFile(filepath.Join(root, ".gitignore"))
+	if os.IsNotExist(err) {
+		return set, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		set.patterns = append(set.patterns, line)
+	}
+	return set, scanner.Err()
+}
+
+// match reports whether relPath (slash-separated, relative to root) should
+// be ignored.
+func (s *ignoreSet) match(relPath string) bool {
+	base := filepath.Base(relPath)
+	for _, raw := range s.patterns {
+		pattern := strings.TrimSuffix(raw, "/")
+		if ok, _ := filepath.Match(pattern, base); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, relPath); ok {
+			return true
+		}
+		if relPath == pattern || strings.HasPrefix(relPath, pattern+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package dbexplain
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// Query is a SQL string literal found at a recognized call site, along with
+// the line it was found on.
+type Query struct {
+	SQL        string
+	LineNumber int
+}
+
+// sqlCallMethods are the method names whose first string-literal argument
+// is treated as a SQL query: database/sql's Query/Exec family, and GORM's
+// Raw/Where.
+var sqlCallMethods = map[string]bool{
+	"Query": true, "QueryContext": true,
+	"QueryRow": true, "QueryRowContext": true,
+	"Exec": true, "ExecContext": true,
+	"Raw":   true,
+	"Where": true,
+}
+
+// ExtractQueries walks node for calls to sqlCallMethods whose first
+// argument is a string literal, returning each SQL literal found.
+func ExtractQueries(node *ast.File, fset *token.FileSet) []Query {
+	var queries []Query
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !sqlCallMethods[sel.Sel.Name] || len(call.Args) == 0 {
+			return true
+		}
+
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+
+		sql, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return true
+		}
+
+		queries = append(queries, Query{
+			SQL:        sql,
+			LineNumber: fset.Position(call.Pos()).Line,
+		})
+		return true
+	})
+
+	return queries
+}
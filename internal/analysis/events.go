@@ -0,0 +1,116 @@
+package analysis
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"time"
+)
+
+// AnalysisEventType identifies what kind of finding an AnalysisEvent carries.
+type AnalysisEventType string
+
+const (
+	EventTypeSecurityIssue    AnalysisEventType = "security_issue"
+	EventTypePerformanceHint  AnalysisEventType = "performance_hint"
+	EventTypeBestPractice     AnalysisEventType = "best_practice"
+	EventTypeAIRecommendation AnalysisEventType = "ai_recommendation"
+	// EventTypeSummary is always the last event AnalyzeCodeStreaming sends,
+	// carrying the complete CodeAnalysis once every finding has been emitted.
+	EventTypeSummary AnalysisEventType = "summary"
+)
+
+// AnalysisEvent is one incremental finding (or the final summary) produced
+// while AnalyzeCodeStreaming runs, so callers such as an SSE handler can
+// render results as they arrive instead of waiting for the whole analysis.
+// Exactly one of the pointer fields is set, matching Type.
+type AnalysisEvent struct {
+	Type             AnalysisEventType `json:"type"`
+	SecurityIssue    *SecurityIssue    `json:"security_issue,omitempty"`
+	PerformanceHint  *PerformanceHint  `json:"performance_hint,omitempty"`
+	BestPractice     *BestPractice     `json:"best_practice,omitempty"`
+	AIRecommendation *AIRecommendation `json:"ai_recommendation,omitempty"`
+	Summary          *CodeAnalysis     `json:"summary,omitempty"`
+}
+
+// AnalyzeCodeStreaming is the streaming core of AnalyzeCode: it runs the same
+// checks but pushes each finding over events as soon as it's produced,
+// followed by a final EventTypeSummary, then closes events. It returns early
+// without sending the summary if ctx is cancelled before analysis completes.
+func AnalyzeCodeStreaming(ctx context.Context, code, codeType, filePath string, events chan<- AnalysisEvent) {
+	defer close(events)
+
+	result := CodeAnalysis{
+		AnalysisTime:      time.Now(),
+		SecurityIssues:    []SecurityIssue{},
+		PerformanceHints:  []PerformanceHint{},
+		BestPractices:     []BestPractice{},
+		AIRecommendations: []AIRecommendation{},
+		FilePath:          filePath,
+	}
+
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, filePath, code, parser.ParseComments)
+	if err != nil {
+		issue := SecurityIssue{
+			Type:        "syntax_error",
+			Description: "Code contains syntax errors",
+			Severity:    "high",
+			Suggestion:  "Fix syntax errors before analysis: " + err.Error(),
+		}
+		result.SecurityIssues = append(result.SecurityIssues, issue)
+		if !sendEvent(ctx, events, AnalysisEvent{Type: EventTypeSecurityIssue, SecurityIssue: &issue}) {
+			return
+		}
+		sendEvent(ctx, events, AnalysisEvent{Type: EventTypeSummary, Summary: &result})
+		return
+	}
+
+	for _, issue := range detectSecurityIssues(node, fset, code) {
+		issue := issue
+		result.SecurityIssues = append(result.SecurityIssues, issue)
+		if !sendEvent(ctx, events, AnalysisEvent{Type: EventTypeSecurityIssue, SecurityIssue: &issue}) {
+			return
+		}
+	}
+
+	for _, hint := range detectPerformanceIssues(node, fset, code) {
+		hint := hint
+		result.PerformanceHints = append(result.PerformanceHints, hint)
+		if !sendEvent(ctx, events, AnalysisEvent{Type: EventTypePerformanceHint, PerformanceHint: &hint}) {
+			return
+		}
+	}
+
+	for _, practice := range suggestBestPractices(node, fset, code) {
+		practice := practice
+		result.BestPractices = append(result.BestPractices, practice)
+		if !sendEvent(ctx, events, AnalysisEvent{Type: EventTypeBestPractice, BestPractice: &practice}) {
+			return
+		}
+	}
+
+	for _, rec := range generateAIRecommendations(code, codeType) {
+		rec := rec
+		result.AIRecommendations = append(result.AIRecommendations, rec)
+		if !sendEvent(ctx, events, AnalysisEvent{Type: EventTypeAIRecommendation, AIRecommendation: &rec}) {
+			return
+		}
+	}
+
+	result.CodeComplexity = calculateComplexity(node)
+	result.PerformanceScore, result.PerformanceGrade = calculatePerformanceScore(result.PerformanceHints)
+
+	sendEvent(ctx, events, AnalysisEvent{Type: EventTypeSummary, Summary: &result})
+}
+
+// sendEvent delivers evt on events, reporting false instead of blocking
+// forever if ctx is cancelled first.
+func sendEvent(ctx context.Context, events chan<- AnalysisEvent, evt AnalysisEvent) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case events <- evt:
+		return true
+	}
+}
@@ -0,0 +1,23 @@
+package langs
+
+import (
+	"context"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+)
+
+func init() {
+	Register(goAnalyzer{})
+}
+
+// goAnalyzer wraps the original, Go-specific analysis.AnalyzeCode heuristics
+// unchanged; it exists so Go is just another registered language rather
+// than a hardcoded special case in cmd/analyzer.
+type goAnalyzer struct{}
+
+func (goAnalyzer) Name() string         { return "go" }
+func (goAnalyzer) Extensions() []string { return []string{".go"} }
+
+func (goAnalyzer) Analyze(_ context.Context, path string, content []byte) (analysis.CodeAnalysis, error) {
+	return analysis.AnalyzeCode(string(content), "go", path), nil
+}
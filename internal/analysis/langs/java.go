@@ -0,0 +1,162 @@
+package langs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/java"
+)
+
+func init() {
+	Register(javaAnalyzer{})
+}
+
+// javaAnalyzer ports the Go analyzer's N+1/raw query/hardcoded secret
+// checks to Hibernate, the idiomatic Java ORM.
+type javaAnalyzer struct{}
+
+func (javaAnalyzer) Name() string         { return "java" }
+func (javaAnalyzer) Extensions() []string { return []string{".java"} }
+
+var javaLoopTypes = map[string]bool{
+	"for_statement": true, "enhanced_for_statement": true, "while_statement": true, "do_statement": true,
+}
+
+// hibernateQueryMethods are Session/Criteria methods distinctive enough to
+// Hibernate that one called per loop iteration always flags as the N+1
+// pattern, regardless of receiver.
+var hibernateQueryMethods = map[string]bool{
+	"load": true, "createQuery": true, "createCriteria": true, "merge": true,
+	"persist": true, "uniqueResult": true,
+}
+
+// hibernateGenericMethods are also Session/Criteria methods, but their names
+// collide with ordinary collection/Optional accessors (list.get(i),
+// optional.get(), map.get(k)), so they only count as N+1 when
+// receiverLooksLikeORM confirms the receiver is actually a session/repository.
+var hibernateGenericMethods = map[string]bool{
+	"get": true, "find": true, "list": true, "save": true, "update": true, "delete": true,
+}
+
+var javaRawQueryMethods = map[string]bool{"createQuery": true, "createSQLQuery": true, "createNativeQuery": true}
+
+var javaSecretNameRE = regexp.MustCompile(`(?i)(password|secret|api[_-]?key|token)`)
+
+func (javaAnalyzer) Analyze(ctx context.Context, path string, content []byte) (analysis.CodeAnalysis, error) {
+	result := analysis.CodeAnalysis{
+		SecurityIssues:   []analysis.SecurityIssue{},
+		PerformanceHints: []analysis.PerformanceHint{},
+		FilePath:         path,
+		AnalysisTime:     time.Now(),
+	}
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(java.GetLanguage())
+
+	tree, err := parser.ParseCtx(ctx, nil, content)
+	if err != nil {
+		result.SecurityIssues = append(result.SecurityIssues, analysis.SecurityIssue{
+			Type:        "syntax_error",
+			Description: "Code contains syntax errors",
+			Severity:    "high",
+			Suggestion:  "Fix syntax errors before analysis: " + err.Error(),
+		})
+		return result, nil
+	}
+	root := tree.RootNode()
+
+	for _, loop := range findLoops(root, javaLoopTypes) {
+		call := findCall(loop, content, "method_invocation", javaCalleeName, func(call *sitter.Node, name string) bool {
+			if hibernateQueryMethods[name] {
+				return true
+			}
+			return hibernateGenericMethods[name] && receiverLooksLikeORM(javaCalleeReceiver(call, content), content)
+		})
+		if call == nil {
+			continue
+		}
+		result.PerformanceHints = append(result.PerformanceHints, analysis.PerformanceHint{
+			Issue:      "Potential N+1 Query Pattern",
+			Impact:     "🔴 CRITICAL: Could execute hundreds of database queries instead of one",
+			Solution:   "Use a JOIN FETCH clause or @BatchSize to fetch associations in one query",
+			Severity:   "critical",
+			LineNumber: line(loop),
+			CodeExample: `// ❌ Bad: N+1 queries
+for (User user : users) {
+    List<Post> posts = session.createQuery("from Post where user = :u").list();
+}
+
+// ✅ Good: join fetch in one query
+session.createQuery("from User u join fetch u.posts").list();`,
+		})
+	}
+
+	walk(root, func(n *sitter.Node) bool {
+		if n.Type() != "method_invocation" || !javaRawQueryMethods[javaCalleeName(n, content)] {
+			return true
+		}
+		args := n.ChildByFieldName("arguments")
+		if args == nil || args.NamedChildCount() == 0 {
+			return true
+		}
+		if javaQueryIsUntrusted(args.NamedChild(0)) {
+			result.SecurityIssues = append(result.SecurityIssues, analysis.SecurityIssue{
+				Type:        "sql_injection_risk",
+				Description: "Raw HQL/SQL query built with string concatenation - potential injection risk",
+				Severity:    "high",
+				LineNumber:  line(n),
+				Suggestion:  "Use named/positional parameters (query.setParameter(...)) instead of concatenation",
+			})
+		}
+		return true
+	})
+
+	walk(root, func(n *sitter.Node) bool {
+		if n.Type() != "variable_declarator" {
+			return true
+		}
+		name := n.ChildByFieldName("name")
+		value := n.ChildByFieldName("value")
+		if name == nil || value == nil || value.Type() != "string_literal" {
+			return true
+		}
+		if javaSecretNameRE.MatchString(name.Content(content)) {
+			result.SecurityIssues = append(result.SecurityIssues, analysis.SecurityIssue{
+				Type:        "potential_hardcoded_secrets",
+				Description: fmt.Sprintf("Potential hardcoded secret assigned to `%s`", name.Content(content)),
+				Severity:    "high",
+				LineNumber:  line(n),
+				Suggestion:  "Use environment variables or a secrets manager for sensitive data",
+			})
+		}
+		return true
+	})
+
+	result.PerformanceScore, result.PerformanceGrade = analysis.ScorePerformance(result.PerformanceHints)
+	return result, nil
+}
+
+func javaCalleeName(call *sitter.Node, source []byte) string {
+	name := call.ChildByFieldName("name")
+	if name == nil {
+		return ""
+	}
+	return name.Content(source)
+}
+
+// javaCalleeReceiver returns the object a method call was made on, e.g.
+// "session" in session.createQuery(...), or nil for an unqualified call.
+func javaCalleeReceiver(call *sitter.Node, source []byte) *sitter.Node {
+	return call.ChildByFieldName("object")
+}
+
+// javaQueryIsUntrusted reports whether arg is a "+" string concatenation,
+// as opposed to a string literal or a variable holding a pre-built
+// parameterized query.
+func javaQueryIsUntrusted(arg *sitter.Node) bool {
+	return arg != nil && arg.Type() == "binary_expression"
+}
@@ -0,0 +1,202 @@
+package langs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+)
+
+func init() {
+	Register(jsAnalyzer{})
+}
+
+// jsAnalyzer covers JavaScript, JSX, TypeScript and TSX, picking the right
+// tree-sitter grammar per extension. It ports the Go analyzer's N+1/raw
+// query/hardcoded secret checks to Sequelize, the idiomatic Node ORM.
+type jsAnalyzer struct{}
+
+func (jsAnalyzer) Name() string         { return "javascript" }
+func (jsAnalyzer) Extensions() []string { return []string{".js", ".jsx", ".ts", ".tsx"} }
+
+var jsLoopTypes = map[string]bool{
+	"for_statement": true, "for_in_statement": true, "while_statement": true, "do_statement": true,
+}
+
+// sequelizeQueryMethods are Sequelize model/query-interface methods
+// distinctive enough that one called per loop iteration always flags as the
+// N+1 pattern the Go analyzer flags via dbMethods, regardless of receiver.
+var sequelizeQueryMethods = map[string]bool{
+	"findAll": true, "findOne": true, "findByPk": true, "findOrCreate": true,
+	"findAndCountAll": true, "destroy": true, "query": true,
+}
+
+// sequelizeGenericMethods are also Sequelize model methods, but their names
+// collide with ordinary object/array APIs (Object.count? no, but "update" and
+// "count" both show up on plain collections), so they only count as N+1 when
+// receiverLooksLikeORM confirms the receiver is actually a model/queryset.
+var sequelizeGenericMethods = map[string]bool{
+	"count": true, "update": true,
+}
+
+var jsRawQueryMethods = map[string]bool{"query": true}
+
+var jsSecretNameRE = regexp.MustCompile(`(?i)(password|secret|api[_-]?key|token)`)
+
+func (a jsAnalyzer) Analyze(ctx context.Context, path string, content []byte) (analysis.CodeAnalysis, error) {
+	result := analysis.CodeAnalysis{
+		SecurityIssues:   []analysis.SecurityIssue{},
+		PerformanceHints: []analysis.PerformanceHint{},
+		FilePath:         path,
+		AnalysisTime:     time.Now(),
+	}
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(a.grammarFor(path))
+
+	tree, err := parser.ParseCtx(ctx, nil, content)
+	if err != nil {
+		result.SecurityIssues = append(result.SecurityIssues, analysis.SecurityIssue{
+			Type:        "syntax_error",
+			Description: "Code contains syntax errors",
+			Severity:    "high",
+			Suggestion:  "Fix syntax errors before analysis: " + err.Error(),
+		})
+		return result, nil
+	}
+	root := tree.RootNode()
+
+	for _, loop := range findLoops(root, jsLoopTypes) {
+		call := findCall(loop, content, "call_expression", jsCalleeName, func(call *sitter.Node, name string) bool {
+			if sequelizeQueryMethods[name] {
+				return true
+			}
+			return sequelizeGenericMethods[name] && receiverLooksLikeORM(jsCalleeReceiver(call, content), content)
+		})
+		if call == nil {
+			continue
+		}
+		result.PerformanceHints = append(result.PerformanceHints, analysis.PerformanceHint{
+			Issue:      "Potential N+1 Query Pattern",
+			Impact:     "🔴 CRITICAL: Could execute hundreds of database queries instead of one",
+			Solution:   "Use Sequelize eager loading (include:) to fetch associations in one query",
+			Severity:   "critical",
+			LineNumber: line(loop),
+			CodeExample: `// ❌ Bad: N+1 queries
+for (const user of users) {
+  const posts = await Post.findAll({ where: { userId: user.id } })
+}
+
+// ✅ Good: eager load in one query
+const users = await User.findAll({ include: Post })`,
+		})
+	}
+
+	walk(root, func(n *sitter.Node) bool {
+		if n.Type() != "call_expression" || !jsRawQueryMethods[jsCalleeName(n, content)] {
+			return true
+		}
+		args := n.ChildByFieldName("arguments")
+		if args == nil || args.NamedChildCount() == 0 {
+			return true
+		}
+		if jsQueryIsUntrusted(args.NamedChild(0)) {
+			result.SecurityIssues = append(result.SecurityIssues, analysis.SecurityIssue{
+				Type:        "sql_injection_risk",
+				Description: "Raw SQL query built from a template string or concatenation - potential injection risk",
+				Severity:    "high",
+				LineNumber:  line(n),
+				Suggestion:  "Use parameterized replacements (sequelize.query(sql, { replacements }))",
+			})
+		}
+		return true
+	})
+
+	walk(root, func(n *sitter.Node) bool {
+		if n.Type() != "variable_declarator" {
+			return true
+		}
+		name := n.ChildByFieldName("name")
+		value := n.ChildByFieldName("value")
+		if name == nil || value == nil || value.Type() != "string" {
+			return true
+		}
+		if jsSecretNameRE.MatchString(name.Content(content)) {
+			result.SecurityIssues = append(result.SecurityIssues, analysis.SecurityIssue{
+				Type:        "potential_hardcoded_secrets",
+				Description: fmt.Sprintf("Potential hardcoded secret assigned to `%s`", name.Content(content)),
+				Severity:    "high",
+				LineNumber:  line(n),
+				Suggestion:  "Use environment variables (process.env) for sensitive data",
+			})
+		}
+		return true
+	})
+
+	result.PerformanceScore, result.PerformanceGrade = analysis.ScorePerformance(result.PerformanceHints)
+	return result, nil
+}
+
+func (jsAnalyzer) grammarFor(path string) *sitter.Language {
+	switch {
+	case strings.HasSuffix(path, ".tsx"):
+		return tsx.GetLanguage()
+	case strings.HasSuffix(path, ".ts"):
+		return typescript.GetLanguage()
+	default:
+		return javascript.GetLanguage()
+	}
+}
+
+func jsCalleeName(call *sitter.Node, source []byte) string {
+	fn := call.ChildByFieldName("function")
+	if fn == nil {
+		return ""
+	}
+	switch fn.Type() {
+	case "member_expression":
+		if prop := fn.ChildByFieldName("property"); prop != nil {
+			return prop.Content(source)
+		}
+	case "identifier":
+		return fn.Content(source)
+	}
+	return ""
+}
+
+// jsCalleeReceiver returns the object a method call was made on, e.g. "User"
+// in User.findAll(...), or nil for a bare function call.
+func jsCalleeReceiver(call *sitter.Node, source []byte) *sitter.Node {
+	fn := call.ChildByFieldName("function")
+	if fn == nil || fn.Type() != "member_expression" {
+		return nil
+	}
+	return fn.ChildByFieldName("object")
+}
+
+// jsQueryIsUntrusted reports whether arg builds a string dynamically (a
+// template literal with an interpolation, or a "+" concatenation) rather
+// than being a plain string literal or a pre-built parameterized query.
+func jsQueryIsUntrusted(arg *sitter.Node) bool {
+	if arg == nil {
+		return false
+	}
+	switch arg.Type() {
+	case "template_string":
+		for i := 0; i < int(arg.ChildCount()); i++ {
+			if arg.Child(i).Type() == "template_substitution" {
+				return true
+			}
+		}
+	case "binary_expression":
+		return true
+	}
+	return false
+}
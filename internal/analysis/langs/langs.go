@@ -0,0 +1,92 @@
+// Package langs lets the analyzer run against more than just Go by
+// registering a LanguageAnalyzer per source language. cmd/analyzer picks the
+// implementation to run from each file's extension, or restricts the set
+// with --languages.
+package langs
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+)
+
+// LanguageAnalyzer analyzes source files written in one language.
+type LanguageAnalyzer interface {
+	// Name is the short identifier used in --languages and in grouped
+	// output, e.g. "go", "python", "javascript".
+	Name() string
+	// Extensions lists the file extensions (with leading dot) this
+	// analyzer claims, e.g. []string{".py"}.
+	Extensions() []string
+	// Analyze runs the analyzer's checks against content and returns the
+	// same result shape as the original Go-only analysis.AnalyzeCode.
+	Analyze(ctx context.Context, path string, content []byte) (analysis.CodeAnalysis, error)
+}
+
+var registry = map[string]LanguageAnalyzer{}
+var byExtension = map[string]LanguageAnalyzer{}
+
+// Register adds a to the registry, keyed by its Name and every extension it
+// claims. It panics on a duplicate name or extension, since that can only
+// happen from a programming error at init time.
+func Register(a LanguageAnalyzer) {
+	if _, exists := registry[a.Name()]; exists {
+		panic("langs: duplicate analyzer name " + a.Name())
+	}
+	registry[a.Name()] = a
+
+	for _, ext := range a.Extensions() {
+		if _, exists := byExtension[ext]; exists {
+			panic("langs: duplicate extension " + ext)
+		}
+		byExtension[ext] = a
+	}
+}
+
+// ForName looks up a registered analyzer by its --languages name.
+func ForName(name string) (LanguageAnalyzer, bool) {
+	a, ok := registry[name]
+	return a, ok
+}
+
+// ForPath returns the analyzer that claims path's extension, if any.
+func ForPath(path string) (LanguageAnalyzer, bool) {
+	a, ok := byExtension[strings.ToLower(filepath.Ext(path))]
+	return a, ok
+}
+
+// Names returns every registered analyzer's name, sorted for stable flag
+// usage text.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse splits a comma-separated --languages value into validated names. An
+// empty csv means "every registered language".
+func Parse(csv string) ([]string, error) {
+	if strings.TrimSpace(csv) == "" {
+		return Names(), nil
+	}
+
+	var names []string
+	for _, name := range strings.Split(csv, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := registry[name]; !ok {
+			return nil, fmt.Errorf("unknown language %q (available: %s)", name, strings.Join(Names(), ", "))
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
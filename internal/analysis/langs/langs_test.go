@@ -0,0 +1,175 @@
+package langs
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAnalyze_SetsAnalysisTime(t *testing.T) {
+	tests := []struct {
+		name string
+		lang LanguageAnalyzer
+		code string
+	}{
+		{"python", pyAnalyzer{}, "for u in users:\n    pass\n"},
+		{"javascript", jsAnalyzer{}, "for (const u of users) {}\n"},
+		{"java", javaAnalyzer{}, "class X { void m() { for (User u : users) {} } }\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.lang.Analyze(context.Background(), "input"+tt.lang.Extensions()[0], []byte(tt.code))
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			if result.AnalysisTime.IsZero() {
+				t.Error("AnalysisTime should be set")
+			}
+		})
+	}
+}
+
+func TestPyAnalyzer_N1Detection(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   string
+		wantN1 bool
+	}{
+		{
+			name: "session.query in a loop is flagged",
+			code: `for user in users:
+    posts = session.query(Post).filter_by(user_id=user.id).all()
+`,
+			wantN1: true,
+		},
+		{
+			name: "generic dict.get in a loop is not an ORM call",
+			code: `for user in users:
+    name = config.get("name")
+`,
+			wantN1: false,
+		},
+		{
+			name: "session.get is still flagged on a session receiver",
+			code: `for user_id in user_ids:
+    user = session.get(User, user_id)
+`,
+			wantN1: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := pyAnalyzer{}.Analyze(context.Background(), "input.py", []byte(tt.code))
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+
+			got := false
+			for _, h := range result.PerformanceHints {
+				if h.Issue == "Potential N+1 Query Pattern" {
+					got = true
+				}
+			}
+			if got != tt.wantN1 {
+				t.Errorf("N+1 flagged = %v, want %v (hints: %+v)", got, tt.wantN1, result.PerformanceHints)
+			}
+		})
+	}
+}
+
+func TestJavaAnalyzer_N1Detection(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   string
+		wantN1 bool
+	}{
+		{
+			name: "session.createQuery in a loop is flagged",
+			code: `class X {
+    void m() {
+        for (User u : users) {
+            session.createQuery("from Post where user = :u").list();
+        }
+    }
+}
+`,
+			wantN1: true,
+		},
+		{
+			name: "list.get in a loop is not an ORM call",
+			code: `class X {
+    void m() {
+        for (User u : users) {
+            Post p = posts.get(0);
+        }
+    }
+}
+`,
+			wantN1: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := javaAnalyzer{}.Analyze(context.Background(), "input.java", []byte(tt.code))
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+
+			got := false
+			for _, h := range result.PerformanceHints {
+				if h.Issue == "Potential N+1 Query Pattern" {
+					got = true
+				}
+			}
+			if got != tt.wantN1 {
+				t.Errorf("N+1 flagged = %v, want %v (hints: %+v)", got, tt.wantN1, result.PerformanceHints)
+			}
+		})
+	}
+}
+
+func TestJsAnalyzer_N1Detection(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   string
+		wantN1 bool
+	}{
+		{
+			name: "Post.findAll in a loop is flagged",
+			code: `for (const user of users) {
+  const posts = await Post.findAll({ where: { userId: user.id } })
+}
+`,
+			wantN1: true,
+		},
+		{
+			name: "map.count in a loop is not an ORM call",
+			code: `for (const user of users) {
+  const n = cache.count(user.id)
+}
+`,
+			wantN1: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := jsAnalyzer{}.Analyze(context.Background(), "input.js", []byte(tt.code))
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+
+			got := false
+			for _, h := range result.PerformanceHints {
+				if h.Issue == "Potential N+1 Query Pattern" {
+					got = true
+				}
+			}
+			if got != tt.wantN1 {
+				t.Errorf("N+1 flagged = %v, want %v (hints: %+v)", got, tt.wantN1, result.PerformanceHints)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package langs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/python"
+)
+
+func init() {
+	Register(pyAnalyzer{})
+}
+
+// pyAnalyzer ports the Go analyzer's N+1/raw query/hardcoded secret checks
+// to SQLAlchemy, the idiomatic Python ORM.
+type pyAnalyzer struct{}
+
+func (pyAnalyzer) Name() string         { return "python" }
+func (pyAnalyzer) Extensions() []string { return []string{".py"} }
+
+var pyLoopTypes = map[string]bool{"for_statement": true, "while_statement": true}
+
+// sqlAlchemyQueryMethods are Session/Query methods distinctive enough to
+// SQLAlchemy that one called per loop iteration always flags as the N+1
+// pattern, regardless of receiver.
+var sqlAlchemyQueryMethods = map[string]bool{
+	"query": true, "filter": true, "filter_by": true, "join": true, "scalar": true,
+}
+
+// sqlAlchemyGenericMethods are also Session/Query methods, but their names
+// are common enough on plain dicts/lists/Optionals (dict.get, list.all? no,
+// but get/first/update/delete overlap with unrelated APIs) that they only
+// count as N+1 when receiverLooksLikeORM confirms the receiver is actually
+// a session/query/queryset.
+var sqlAlchemyGenericMethods = map[string]bool{
+	"all": true, "first": true, "one": true, "get": true,
+	"execute": true, "add": true, "commit": true, "delete": true, "update": true,
+}
+
+var pyRawQueryMethods = map[string]bool{"execute": true}
+
+var pySecretNameRE = regexp.MustCompile(`(?i)(password|secret|api[_-]?key|token)`)
+
+func (pyAnalyzer) Analyze(ctx context.Context, path string, content []byte) (analysis.CodeAnalysis, error) {
+	result := analysis.CodeAnalysis{
+		SecurityIssues:   []analysis.SecurityIssue{},
+		PerformanceHints: []analysis.PerformanceHint{},
+		FilePath:         path,
+		AnalysisTime:     time.Now(),
+	}
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(python.GetLanguage())
+
+	tree, err := parser.ParseCtx(ctx, nil, content)
+	if err != nil {
+		result.SecurityIssues = append(result.SecurityIssues, analysis.SecurityIssue{
+			Type:        "syntax_error",
+			Description: "Code contains syntax errors",
+			Severity:    "high",
+			Suggestion:  "Fix syntax errors before analysis: " + err.Error(),
+		})
+		return result, nil
+	}
+	root := tree.RootNode()
+
+	for _, loop := range findLoops(root, pyLoopTypes) {
+		call := findCall(loop, content, "call", pyCalleeName, func(call *sitter.Node, name string) bool {
+			if sqlAlchemyQueryMethods[name] {
+				return true
+			}
+			return sqlAlchemyGenericMethods[name] && receiverLooksLikeORM(pyCalleeReceiver(call, content), content)
+		})
+		if call == nil {
+			continue
+		}
+		result.PerformanceHints = append(result.PerformanceHints, analysis.PerformanceHint{
+			Issue:      "Potential N+1 Query Pattern",
+			Impact:     "🔴 CRITICAL: Could execute hundreds of database queries instead of one",
+			Solution:   "Use joinedload()/selectinload() to eager load relationships in one query",
+			Severity:   "critical",
+			LineNumber: line(loop),
+			CodeExample: `# ❌ Bad: N+1 queries
+for user in users:
+    posts = session.query(Post).filter_by(user_id=user.id).all()
+
+# ✅ Good: eager load in one query
+users = session.query(User).options(joinedload(User.posts)).all()`,
+		})
+	}
+
+	walk(root, func(n *sitter.Node) bool {
+		if n.Type() != "call" || !pyRawQueryMethods[pyCalleeName(n, content)] {
+			return true
+		}
+		args := n.ChildByFieldName("arguments")
+		if args == nil || args.NamedChildCount() == 0 {
+			return true
+		}
+		if pyQueryIsUntrusted(args.NamedChild(0), content) {
+			result.SecurityIssues = append(result.SecurityIssues, analysis.SecurityIssue{
+				Type:        "sql_injection_risk",
+				Description: "Raw SQL query built from an f-string or concatenation - potential injection risk",
+				Severity:    "high",
+				LineNumber:  line(n),
+				Suggestion:  "Use bound parameters (session.execute(text(sql), params))",
+			})
+		}
+		return true
+	})
+
+	walk(root, func(n *sitter.Node) bool {
+		if n.Type() != "assignment" {
+			return true
+		}
+		left := n.ChildByFieldName("left")
+		right := n.ChildByFieldName("right")
+		if left == nil || right == nil || left.Type() != "identifier" || right.Type() != "string" {
+			return true
+		}
+		if pySecretNameRE.MatchString(left.Content(content)) {
+			result.SecurityIssues = append(result.SecurityIssues, analysis.SecurityIssue{
+				Type:        "potential_hardcoded_secrets",
+				Description: fmt.Sprintf("Potential hardcoded secret assigned to `%s`", left.Content(content)),
+				Severity:    "high",
+				LineNumber:  line(n),
+				Suggestion:  "Use environment variables (os.environ) for sensitive data",
+			})
+		}
+		return true
+	})
+
+	if issue := pyMissingMiddlewareIssue(string(content)); issue != nil {
+		result.SecurityIssues = append(result.SecurityIssues, *issue)
+	}
+
+	result.PerformanceScore, result.PerformanceGrade = analysis.ScorePerformance(result.PerformanceHints)
+	return result, nil
+}
+
+// pyMissingMiddlewareIssue flags a Flask/Django/FastAPI app that has no CORS
+// middleware configured, mirroring the Go analyzer's missing_cors check.
+func pyMissingMiddlewareIssue(code string) *analysis.SecurityIssue {
+	var framework string
+	switch {
+	case strings.Contains(code, "FastAPI("):
+		framework = "FastAPI"
+	case strings.Contains(code, "Flask("):
+		framework = "Flask"
+	case strings.Contains(code, "django"):
+		framework = "Django"
+	default:
+		return nil
+	}
+
+	if strings.Contains(code, "CORS") || strings.Contains(code, "cors") {
+		return nil
+	}
+
+	return &analysis.SecurityIssue{
+		Type:        "missing_cors",
+		Description: fmt.Sprintf("No CORS middleware detected for this %s app - this can cause browser security issues", framework),
+		Severity:    "medium",
+		Suggestion:  pyMiddlewareSuggestion(framework),
+	}
+}
+
+func pyMiddlewareSuggestion(framework string) string {
+	switch framework {
+	case "FastAPI":
+		return "Add CORSMiddleware: app.add_middleware(CORSMiddleware, allow_origins=[...])"
+	case "Flask":
+		return "Add flask-cors: CORS(app)"
+	default:
+		return "Add django-cors-headers and list it in MIDDLEWARE/INSTALLED_APPS"
+	}
+}
+
+func pyCalleeName(call *sitter.Node, source []byte) string {
+	fn := call.ChildByFieldName("function")
+	if fn == nil {
+		return ""
+	}
+	switch fn.Type() {
+	case "attribute":
+		if attr := fn.ChildByFieldName("attribute"); attr != nil {
+			return attr.Content(source)
+		}
+	case "identifier":
+		return fn.Content(source)
+	}
+	return ""
+}
+
+// pyCalleeReceiver returns the object a method call was made on, e.g.
+// "session" in session.query(...), or nil for a bare function call.
+func pyCalleeReceiver(call *sitter.Node, source []byte) *sitter.Node {
+	fn := call.ChildByFieldName("function")
+	if fn == nil || fn.Type() != "attribute" {
+		return nil
+	}
+	return fn.ChildByFieldName("object")
+}
+
+// pyQueryIsUntrusted reports whether arg is an f-string interpolation or a
+// "+" concatenation, as opposed to a plain string literal or a variable
+// holding a pre-built parameterized query.
+func pyQueryIsUntrusted(arg *sitter.Node, source []byte) bool {
+	if arg == nil {
+		return false
+	}
+	switch arg.Type() {
+	case "string":
+		found := false
+		walk(arg, func(n *sitter.Node) bool {
+			if strings.Contains(n.Type(), "interpolation") {
+				found = true
+				return false
+			}
+			return true
+		})
+		return found
+	case "binary_operator":
+		op := arg.ChildByFieldName("operator")
+		return op != nil && op.Content(source) == "+"
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package langs
+
+import (
+	"regexp"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// findLoops returns every node in tree whose type is in loopTypes, e.g.
+// {"for_statement": true} for a Python/Java grammar.
+func findLoops(root *sitter.Node, loopTypes map[string]bool) []*sitter.Node {
+	var loops []*sitter.Node
+	walk(root, func(n *sitter.Node) bool {
+		if loopTypes[n.Type()] {
+			loops = append(loops, n)
+		}
+		return true
+	})
+	return loops
+}
+
+// findCall returns the first descendant of node whose type is callType (e.g.
+// "call_expression") and whose callee name, as extracted by calleeName,
+// satisfies isMatch. isMatch also receives the call node itself, so a
+// generic-sounding method name (get, find, all, ...) can be confirmed
+// against its receiver before matching. Returns nil if none is found.
+func findCall(node *sitter.Node, source []byte, callType string, calleeName func(call *sitter.Node, source []byte) string, isMatch func(call *sitter.Node, name string) bool) *sitter.Node {
+	var found *sitter.Node
+	walk(node, func(n *sitter.Node) bool {
+		if found != nil {
+			return false
+		}
+		if n.Type() == callType && isMatch(n, calleeName(n, source)) {
+			found = n
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// ormReceiverRE matches a call receiver that looks like an ORM
+// session/repository/queryset, as opposed to an ordinary collection, map,
+// or Optional. It gates method names too generic to trust on their own
+// (get, find, list, all, first, count, save, update, delete): those only
+// count as the N+1 pattern when called on something that looks like this.
+var ormReceiverRE = regexp.MustCompile(`(?i)(session|entitymanager|\bem\b|criteria|\bquery\b|queryset|\bqs\b|repository|\brepo\b|\bdao\b|\bdb\b|objects|manager)`)
+
+// receiverLooksLikeORM reports whether receiver's text (the object a method
+// was called on) matches ormReceiverRE.
+func receiverLooksLikeORM(receiver *sitter.Node, source []byte) bool {
+	return receiver != nil && ormReceiverRE.MatchString(receiver.Content(source))
+}
+
+// walk visits node and every descendant depth-first, stopping early if
+// visit returns false for a node (its children are then skipped, but
+// traversal continues with its siblings).
+func walk(node *sitter.Node, visit func(*sitter.Node) bool) {
+	if node == nil {
+		return
+	}
+	if !visit(node) {
+		return
+	}
+	for i := 0; i < int(node.ChildCount()); i++ {
+		walk(node.Child(i), visit)
+	}
+}
+
+// line converts a tree-sitter (0-based) start point into a 1-based line
+// number, matching the convention the Go go/token-based checks already use.
+func line(n *sitter.Node) int {
+	return int(n.StartPoint().Row) + 1
+}
@@ -0,0 +1,67 @@
+// Package metrics instruments analysis runs with Prometheus collectors so
+// an analyzer process can be scraped over time, trending performance and
+// security posture across a codebase instead of only ever showing a
+// one-shot report.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	filesAnalyzed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "apiperf_files_analyzed_total",
+		Help: "Total number of files run through a language analyzer.",
+	})
+
+	securityIssuesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "apiperf_security_issues_total",
+		Help: "Security issues found, labeled by SecurityIssue.Type.",
+	}, []string{"type"})
+
+	performanceHintsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "apiperf_performance_hints_total",
+		Help: "Performance hints found, labeled by PerformanceHint.Issue.",
+	}, []string{"issue"})
+
+	analysisDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "apiperf_analysis_duration_seconds",
+		Help:    "Time a single file's analysis took.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	performanceScore = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "apiperf_performance_score",
+		Help: "Most recent PerformanceScore for a file, labeled by file_path.",
+	}, []string{"file_path"})
+)
+
+// Observe records one completed analysis: the files-analyzed counter, the
+// per-type/per-issue counters, the duration histogram, and the latest
+// PerformanceScore gauge for result.FilePath. Call it once per file, right
+// after the analyzer that produced result returns.
+func Observe(result analysis.CodeAnalysis, duration time.Duration) {
+	filesAnalyzed.Inc()
+	analysisDuration.Observe(duration.Seconds())
+
+	for _, issue := range result.SecurityIssues {
+		securityIssuesTotal.WithLabelValues(issue.Type).Inc()
+	}
+	for _, hint := range result.PerformanceHints {
+		performanceHintsTotal.WithLabelValues(hint.Issue).Inc()
+	}
+	if result.FilePath != "" {
+		performanceScore.WithLabelValues(result.FilePath).Set(float64(result.PerformanceScore))
+	}
+}
+
+// Handler returns the HTTP handler to serve at /metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
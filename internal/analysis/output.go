@@ -0,0 +1,155 @@
+package analysis
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// toolVersion is mixed into a ToSARIF report's tool.driver block. It has no
+// relation to the rest of the analyzer's versioning; bump it alongside any
+// change to the fields this package reports.
+const toolVersion = "1.0.0"
+
+// ToSARIF renders a as a SARIF 2.1.0 log with a single run: every
+// SecurityIssue and PerformanceHint becomes one results[] entry, so
+// POST /api/v1/analyze can hand SARIF straight to tools that expect it
+// (e.g. GitHub code scanning) without a round trip through the CLI.
+func (a CodeAnalysis) ToSARIF() ([]byte, error) {
+	filePath := a.FilePath
+	if filePath == "" {
+		filePath = "input.go"
+	}
+
+	var results []map[string]interface{}
+	for _, issue := range a.SecurityIssues {
+		results = append(results, sarifResult(issue.Type, mapSeverityToSARIFLevel(issue.Severity), issue.Description, filePath, issue.LineNumber))
+	}
+	for _, hint := range a.PerformanceHints {
+		results = append(results, sarifResult(hint.Issue, mapSeverityToSARIFLevel(hint.Severity), hint.Impact, filePath, hint.LineNumber))
+	}
+
+	sarif := map[string]interface{}{
+		"version": "2.1.0",
+		"$schema": "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		"runs": []map[string]interface{}{
+			{
+				"tool": map[string]interface{}{
+					"driver": map[string]interface{}{
+						"name":    "API Performance Analyzer",
+						"version": toolVersion,
+					},
+				},
+				"results": results,
+			},
+		},
+	}
+
+	return json.MarshalIndent(sarif, "", "  ")
+}
+
+// sarifResult builds one SARIF results[] entry.
+func sarifResult(ruleID, level, message, filePath string, lineNumber int) map[string]interface{} {
+	line := lineNumber
+	if line <= 0 {
+		line = 1
+	}
+	return map[string]interface{}{
+		"ruleId":  ruleID,
+		"level":   level,
+		"message": map[string]string{"text": message},
+		"locations": []map[string]interface{}{
+			{
+				"physicalLocation": map[string]interface{}{
+					"artifactLocation": map[string]string{"uri": filePath},
+					"region":           map[string]int{"startLine": line},
+				},
+			},
+		},
+	}
+}
+
+// mapSeverityToSARIFLevel maps a SecurityIssue/PerformanceHint Severity to
+// the SARIF result level vocabulary (error/warning/note).
+func mapSeverityToSARIFLevel(severity string) string {
+	switch severity {
+	case "critical", "high":
+		return "error"
+	case "medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// junitTestSuites is the root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ToJUnit renders a as JUnit XML: every SecurityIssue and PerformanceHint
+// becomes a <failure> inside a synthetic <testcase>, grouped into a
+// "security" and a "performance" <testsuite>, so CI systems that already
+// surface JUnit results can show POST /api/v1/analyze findings as test
+// failures without going through the CLI.
+func (a CodeAnalysis) ToJUnit() ([]byte, error) {
+	filePath := a.FilePath
+	if filePath == "" {
+		filePath = "input.go"
+	}
+
+	security := junitTestSuite{Name: "security"}
+	for _, issue := range a.SecurityIssues {
+		security.Tests++
+		security.Failures++
+		security.Cases = append(security.Cases, junitTestCase{
+			ClassName: filePath,
+			Name:      fmt.Sprintf("%s (line %d)", strings.ReplaceAll(issue.Type, "_", " "), issue.LineNumber),
+			Failure: &junitFailure{
+				Message: issue.Description,
+				Text:    issue.Suggestion,
+			},
+		})
+	}
+
+	performance := junitTestSuite{Name: "performance"}
+	for _, hint := range a.PerformanceHints {
+		performance.Tests++
+		performance.Failures++
+		performance.Cases = append(performance.Cases, junitTestCase{
+			ClassName: filePath,
+			Name:      fmt.Sprintf("%s (line %d)", hint.Issue, hint.LineNumber),
+			Failure: &junitFailure{
+				Message: hint.Impact,
+				Text:    hint.Solution,
+			},
+		})
+	}
+
+	suites := junitTestSuites{Suites: []junitTestSuite{security, performance}}
+	out, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
@@ -0,0 +1,13 @@
+package store
+
+import "github.com/aldookware/api-performance-analyzer/internal/analysis/langs"
+
+// languageFor derives the language column Save records for filePath: the
+// name of the LanguageAnalyzer registered for its extension, or "go" for an
+// unregistered or empty extension.
+func languageFor(filePath string) string {
+	if lang, ok := langs.ForPath(filePath); ok {
+		return lang.Name()
+	}
+	return "go"
+}
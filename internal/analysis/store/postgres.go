@@ -0,0 +1,356 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	_ "github.com/lib/pq"
+)
+
+// Postgres is a Store backend for production deployments that already run
+// Postgres: normalized tables keyed by (file_path, analysis_time, git_sha),
+// migrated on NewPostgres.
+type Postgres struct {
+	db *sql.DB
+}
+
+// NewPostgres opens dsn, applies pool, and migrates the schema. The
+// returned *Postgres owns the *sql.DB and should be closed with Close.
+func NewPostgres(dsn string, pool PoolConfig) (*Postgres, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open postgres: %w", err)
+	}
+
+	if pool.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(pool.MaxOpenConns)
+	}
+	if pool.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+	if pool.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping postgres: %w", err)
+	}
+
+	p := &Postgres{db: db}
+	if err := p.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+	return p, nil
+}
+
+func (p *Postgres) Close() error {
+	return p.db.Close()
+}
+
+// migrate creates the schema if it doesn't already exist. There is
+// intentionally no migration framework here: the schema is additive and
+// small enough that "CREATE TABLE IF NOT EXISTS" on startup is sufficient.
+func (p *Postgres) migrate() error {
+	const schema = `
+CREATE TABLE IF NOT EXISTS analyses (
+	id                 BIGSERIAL PRIMARY KEY,
+	file_path          TEXT NOT NULL,
+	language           TEXT NOT NULL,
+	git_sha            TEXT NOT NULL,
+	analysis_time      TIMESTAMPTZ NOT NULL,
+	code_complexity    INT NOT NULL,
+	performance_score  INT NOT NULL,
+	performance_grade  TEXT NOT NULL,
+	UNIQUE (file_path, analysis_time, git_sha)
+);
+
+CREATE INDEX IF NOT EXISTS analyses_file_path_idx ON analyses (file_path, analysis_time);
+CREATE INDEX IF NOT EXISTS analyses_analysis_time_idx ON analyses (analysis_time);
+CREATE INDEX IF NOT EXISTS analyses_language_idx ON analyses (language);
+
+CREATE TABLE IF NOT EXISTS security_issues (
+	id           BIGSERIAL PRIMARY KEY,
+	analysis_id  BIGINT NOT NULL REFERENCES analyses(id) ON DELETE CASCADE,
+	type         TEXT NOT NULL,
+	description  TEXT NOT NULL,
+	severity     TEXT NOT NULL,
+	line_number  INT NOT NULL,
+	suggestion   TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS performance_hints (
+	id            BIGSERIAL PRIMARY KEY,
+	analysis_id   BIGINT NOT NULL REFERENCES analyses(id) ON DELETE CASCADE,
+	issue         TEXT NOT NULL,
+	impact        TEXT NOT NULL,
+	solution      TEXT NOT NULL,
+	code_example  TEXT NOT NULL,
+	line_number   INT NOT NULL,
+	severity      TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS ai_recommendations (
+	id              BIGSERIAL PRIMARY KEY,
+	analysis_id     BIGINT NOT NULL REFERENCES analyses(id) ON DELETE CASCADE,
+	type            TEXT NOT NULL,
+	confidence      DOUBLE PRECISION NOT NULL,
+	recommendation  TEXT NOT NULL,
+	auto_fix_code   TEXT NOT NULL
+);
+`
+	_, err := p.db.Exec(schema)
+	return err
+}
+
+// Save implements Store.
+func (p *Postgres) Save(ctx context.Context, result analysis.CodeAnalysis, gitSHA string) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var analysisID int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO analyses (file_path, language, git_sha, analysis_time, code_complexity, performance_score, performance_grade)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (file_path, analysis_time, git_sha) DO UPDATE SET file_path = EXCLUDED.file_path
+		RETURNING id`,
+		result.FilePath, languageFor(result.FilePath), gitSHA, result.AnalysisTime, result.CodeComplexity, result.PerformanceScore, result.PerformanceGrade,
+	).Scan(&analysisID)
+	if err != nil {
+		return fmt.Errorf("insert analysis: %w", err)
+	}
+
+	for _, issue := range result.SecurityIssues {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO security_issues (analysis_id, type, description, severity, line_number, suggestion)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			analysisID, issue.Type, issue.Description, issue.Severity, issue.LineNumber, issue.Suggestion,
+		); err != nil {
+			return fmt.Errorf("insert security issue: %w", err)
+		}
+	}
+
+	for _, hint := range result.PerformanceHints {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO performance_hints (analysis_id, issue, impact, solution, code_example, line_number, severity)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			analysisID, hint.Issue, hint.Impact, hint.Solution, hint.CodeExample, hint.LineNumber, hint.Severity,
+		); err != nil {
+			return fmt.Errorf("insert performance hint: %w", err)
+		}
+	}
+
+	for _, rec := range result.AIRecommendations {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO ai_recommendations (analysis_id, type, confidence, recommendation, auto_fix_code)
+			VALUES ($1, $2, $3, $4, $5)`,
+			analysisID, rec.Type, rec.Confidence, rec.Recommendation, rec.AutoFixCode,
+		); err != nil {
+			return fmt.Errorf("insert ai recommendation: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// HistoryForFile implements Store.
+func (p *Postgres) HistoryForFile(ctx context.Context, path string) ([]analysis.CodeAnalysis, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, analysis_time, code_complexity, performance_score, performance_grade
+		FROM analyses
+		WHERE file_path = $1
+		ORDER BY analysis_time ASC`, path)
+	if err != nil {
+		return nil, fmt.Errorf("query analyses: %w", err)
+	}
+	defer rows.Close()
+
+	var results []analysis.CodeAnalysis
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		a := analysis.CodeAnalysis{FilePath: path}
+		if err := rows.Scan(&id, &a.AnalysisTime, &a.CodeComplexity, &a.PerformanceScore, &a.PerformanceGrade); err != nil {
+			return nil, fmt.Errorf("scan analysis: %w", err)
+		}
+		ids = append(ids, id)
+		results = append(results, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, id := range ids {
+		issues, err := p.securityIssuesFor(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		results[i].SecurityIssues = issues
+
+		hints, err := p.performanceHintsFor(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		results[i].PerformanceHints = hints
+	}
+
+	return results, nil
+}
+
+func (p *Postgres) securityIssuesFor(ctx context.Context, analysisID int64) ([]analysis.SecurityIssue, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT type, description, severity, line_number, suggestion
+		FROM security_issues WHERE analysis_id = $1`, analysisID)
+	if err != nil {
+		return nil, fmt.Errorf("query security issues: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []analysis.SecurityIssue
+	for rows.Next() {
+		var issue analysis.SecurityIssue
+		if err := rows.Scan(&issue.Type, &issue.Description, &issue.Severity, &issue.LineNumber, &issue.Suggestion); err != nil {
+			return nil, fmt.Errorf("scan security issue: %w", err)
+		}
+		issues = append(issues, issue)
+	}
+	return issues, rows.Err()
+}
+
+func (p *Postgres) performanceHintsFor(ctx context.Context, analysisID int64) ([]analysis.PerformanceHint, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT issue, impact, solution, code_example, line_number, severity
+		FROM performance_hints WHERE analysis_id = $1`, analysisID)
+	if err != nil {
+		return nil, fmt.Errorf("query performance hints: %w", err)
+	}
+	defer rows.Close()
+
+	var hints []analysis.PerformanceHint
+	for rows.Next() {
+		var hint analysis.PerformanceHint
+		if err := rows.Scan(&hint.Issue, &hint.Impact, &hint.Solution, &hint.CodeExample, &hint.LineNumber, &hint.Severity); err != nil {
+			return nil, fmt.Errorf("scan performance hint: %w", err)
+		}
+		hints = append(hints, hint)
+	}
+	return hints, rows.Err()
+}
+
+// History implements Store.
+func (p *Postgres) History(ctx context.Context, since time.Time, language string) ([]analysis.CodeAnalysis, error) {
+	query := `
+		SELECT id, file_path, analysis_time, code_complexity, performance_score, performance_grade
+		FROM analyses
+		WHERE analysis_time >= $1`
+	args := []interface{}{since}
+	if language != "" {
+		query += " AND language = $2"
+		args = append(args, language)
+	}
+	query += " ORDER BY analysis_time ASC"
+
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query history: %w", err)
+	}
+	defer rows.Close()
+
+	var results []analysis.CodeAnalysis
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		var a analysis.CodeAnalysis
+		if err := rows.Scan(&id, &a.FilePath, &a.AnalysisTime, &a.CodeComplexity, &a.PerformanceScore, &a.PerformanceGrade); err != nil {
+			return nil, fmt.Errorf("scan analysis: %w", err)
+		}
+		ids = append(ids, id)
+		results = append(results, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, id := range ids {
+		issues, err := p.securityIssuesFor(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		results[i].SecurityIssues = issues
+
+		hints, err := p.performanceHintsFor(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		results[i].PerformanceHints = hints
+	}
+
+	return results, nil
+}
+
+// ScoreTrend implements Store.
+func (p *Postgres) ScoreTrend(ctx context.Context, since time.Time, bucket string) ([]ScorePoint, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT date_trunc($1, analysis_time) AS bucket, AVG(performance_score)
+		FROM analyses
+		WHERE analysis_time >= $2
+		GROUP BY bucket
+		ORDER BY bucket ASC`, bucketOrDefault(bucket), since)
+	if err != nil {
+		return nil, fmt.Errorf("query score trend: %w", err)
+	}
+	defer rows.Close()
+
+	var points []ScorePoint
+	for rows.Next() {
+		var p ScorePoint
+		if err := rows.Scan(&p.Bucket, &p.AverageScore); err != nil {
+			return nil, fmt.Errorf("scan score point: %w", err)
+		}
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
+
+// Count implements Store.
+func (p *Postgres) Count(ctx context.Context) (int, error) {
+	var n int
+	if err := p.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM analyses`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count analyses: %w", err)
+	}
+	return n, nil
+}
+
+// TopIssues implements Store.
+func (p *Postgres) TopIssues(ctx context.Context, n int) ([]IssueCount, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT kind, name, COUNT(*) AS count FROM (
+			SELECT 'security' AS kind, type AS name FROM security_issues
+			UNION ALL
+			SELECT 'performance' AS kind, issue AS name FROM performance_hints
+		) combined
+		GROUP BY kind, name
+		ORDER BY count DESC
+		LIMIT $1`, n)
+	if err != nil {
+		return nil, fmt.Errorf("query top issues: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []IssueCount
+	for rows.Next() {
+		var c IssueCount
+		if err := rows.Scan(&c.Kind, &c.Name, &c.Count); err != nil {
+			return nil, fmt.Errorf("scan issue count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
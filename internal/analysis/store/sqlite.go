@@ -0,0 +1,385 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	_ "modernc.org/sqlite"
+)
+
+// SQLite is the zero-config Store backend: a single file, no CGO, no
+// server to stand up. It's what Open returns for any dsn that isn't a
+// Postgres connection string, so a local run or a CI container without a
+// database gets history for free.
+type SQLite struct {
+	db *sql.DB
+}
+
+// NewSQLite opens path (a file path; "" defaults to "analyzer.db" in the
+// working directory) and migrates the schema. The returned *SQLite owns the
+// *sql.DB and should be closed with Close.
+func NewSQLite(path string, pool PoolConfig) (*SQLite, error) {
+	if path == "" {
+		path = "analyzer.db"
+	}
+
+	dsn := path
+	if !strings.Contains(dsn, "?") {
+		// Enables ON DELETE CASCADE; SQLite ignores foreign keys unless
+		// this is set per-connection, so it rides along in the DSN instead
+		// of a one-off PRAGMA that pool.Get might not reuse.
+		dsn += "?_pragma=foreign_keys(1)"
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite: %w", err)
+	}
+
+	if pool.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(pool.MaxOpenConns)
+	}
+	if pool.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+	if pool.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping sqlite: %w", err)
+	}
+
+	s := &SQLite{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+	return s, nil
+}
+
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
+
+// migrate creates the schema if it doesn't already exist, mirroring
+// Postgres.migrate in shape (see that method for why there's no migration
+// framework).
+func (s *SQLite) migrate() error {
+	const schema = `
+CREATE TABLE IF NOT EXISTS analyses (
+	id                 INTEGER PRIMARY KEY AUTOINCREMENT,
+	file_path          TEXT NOT NULL,
+	language           TEXT NOT NULL,
+	git_sha            TEXT NOT NULL,
+	analysis_time      DATETIME NOT NULL,
+	code_complexity    INTEGER NOT NULL,
+	performance_score  INTEGER NOT NULL,
+	performance_grade  TEXT NOT NULL,
+	UNIQUE (file_path, analysis_time, git_sha)
+);
+
+CREATE INDEX IF NOT EXISTS analyses_file_path_idx ON analyses (file_path, analysis_time);
+CREATE INDEX IF NOT EXISTS analyses_analysis_time_idx ON analyses (analysis_time);
+CREATE INDEX IF NOT EXISTS analyses_language_idx ON analyses (language);
+
+CREATE TABLE IF NOT EXISTS security_issues (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	analysis_id  INTEGER NOT NULL REFERENCES analyses(id) ON DELETE CASCADE,
+	type         TEXT NOT NULL,
+	description  TEXT NOT NULL,
+	severity     TEXT NOT NULL,
+	line_number  INTEGER NOT NULL,
+	suggestion   TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS performance_hints (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	analysis_id   INTEGER NOT NULL REFERENCES analyses(id) ON DELETE CASCADE,
+	issue         TEXT NOT NULL,
+	impact        TEXT NOT NULL,
+	solution      TEXT NOT NULL,
+	code_example  TEXT NOT NULL,
+	line_number   INTEGER NOT NULL,
+	severity      TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS ai_recommendations (
+	id              INTEGER PRIMARY KEY AUTOINCREMENT,
+	analysis_id     INTEGER NOT NULL REFERENCES analyses(id) ON DELETE CASCADE,
+	type            TEXT NOT NULL,
+	confidence      REAL NOT NULL,
+	recommendation  TEXT NOT NULL,
+	auto_fix_code   TEXT NOT NULL
+);
+`
+	_, err := s.db.Exec(schema)
+	return err
+}
+
+// Save implements Store.
+func (s *SQLite) Save(ctx context.Context, result analysis.CodeAnalysis, gitSHA string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO analyses (file_path, language, git_sha, analysis_time, code_complexity, performance_score, performance_grade)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(file_path, analysis_time, git_sha) DO UPDATE SET file_path = excluded.file_path`,
+		result.FilePath, languageFor(result.FilePath), gitSHA, result.AnalysisTime, result.CodeComplexity, result.PerformanceScore, result.PerformanceGrade,
+	)
+	if err != nil {
+		return fmt.Errorf("insert analysis: %w", err)
+	}
+	analysisID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("insert analysis: %w", err)
+	}
+
+	for _, issue := range result.SecurityIssues {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO security_issues (analysis_id, type, description, severity, line_number, suggestion)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			analysisID, issue.Type, issue.Description, issue.Severity, issue.LineNumber, issue.Suggestion,
+		); err != nil {
+			return fmt.Errorf("insert security issue: %w", err)
+		}
+	}
+
+	for _, hint := range result.PerformanceHints {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO performance_hints (analysis_id, issue, impact, solution, code_example, line_number, severity)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			analysisID, hint.Issue, hint.Impact, hint.Solution, hint.CodeExample, hint.LineNumber, hint.Severity,
+		); err != nil {
+			return fmt.Errorf("insert performance hint: %w", err)
+		}
+	}
+
+	for _, rec := range result.AIRecommendations {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO ai_recommendations (analysis_id, type, confidence, recommendation, auto_fix_code)
+			VALUES (?, ?, ?, ?, ?)`,
+			analysisID, rec.Type, rec.Confidence, rec.Recommendation, rec.AutoFixCode,
+		); err != nil {
+			return fmt.Errorf("insert ai recommendation: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// HistoryForFile implements Store.
+func (s *SQLite) HistoryForFile(ctx context.Context, path string) ([]analysis.CodeAnalysis, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, analysis_time, code_complexity, performance_score, performance_grade
+		FROM analyses
+		WHERE file_path = ?
+		ORDER BY analysis_time ASC`, path)
+	if err != nil {
+		return nil, fmt.Errorf("query analyses: %w", err)
+	}
+	defer rows.Close()
+
+	var results []analysis.CodeAnalysis
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		a := analysis.CodeAnalysis{FilePath: path}
+		if err := rows.Scan(&id, &a.AnalysisTime, &a.CodeComplexity, &a.PerformanceScore, &a.PerformanceGrade); err != nil {
+			return nil, fmt.Errorf("scan analysis: %w", err)
+		}
+		ids = append(ids, id)
+		results = append(results, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, id := range ids {
+		issues, err := s.securityIssuesFor(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		results[i].SecurityIssues = issues
+
+		hints, err := s.performanceHintsFor(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		results[i].PerformanceHints = hints
+	}
+
+	return results, nil
+}
+
+func (s *SQLite) securityIssuesFor(ctx context.Context, analysisID int64) ([]analysis.SecurityIssue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT type, description, severity, line_number, suggestion
+		FROM security_issues WHERE analysis_id = ?`, analysisID)
+	if err != nil {
+		return nil, fmt.Errorf("query security issues: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []analysis.SecurityIssue
+	for rows.Next() {
+		var issue analysis.SecurityIssue
+		if err := rows.Scan(&issue.Type, &issue.Description, &issue.Severity, &issue.LineNumber, &issue.Suggestion); err != nil {
+			return nil, fmt.Errorf("scan security issue: %w", err)
+		}
+		issues = append(issues, issue)
+	}
+	return issues, rows.Err()
+}
+
+func (s *SQLite) performanceHintsFor(ctx context.Context, analysisID int64) ([]analysis.PerformanceHint, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue, impact, solution, code_example, line_number, severity
+		FROM performance_hints WHERE analysis_id = ?`, analysisID)
+	if err != nil {
+		return nil, fmt.Errorf("query performance hints: %w", err)
+	}
+	defer rows.Close()
+
+	var hints []analysis.PerformanceHint
+	for rows.Next() {
+		var hint analysis.PerformanceHint
+		if err := rows.Scan(&hint.Issue, &hint.Impact, &hint.Solution, &hint.CodeExample, &hint.LineNumber, &hint.Severity); err != nil {
+			return nil, fmt.Errorf("scan performance hint: %w", err)
+		}
+		hints = append(hints, hint)
+	}
+	return hints, rows.Err()
+}
+
+// History implements Store.
+func (s *SQLite) History(ctx context.Context, since time.Time, language string) ([]analysis.CodeAnalysis, error) {
+	query := `
+		SELECT id, file_path, analysis_time, code_complexity, performance_score, performance_grade
+		FROM analyses
+		WHERE analysis_time >= ?`
+	args := []interface{}{since}
+	if language != "" {
+		query += " AND language = ?"
+		args = append(args, language)
+	}
+	query += " ORDER BY analysis_time ASC"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query history: %w", err)
+	}
+	defer rows.Close()
+
+	var results []analysis.CodeAnalysis
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		var a analysis.CodeAnalysis
+		if err := rows.Scan(&id, &a.FilePath, &a.AnalysisTime, &a.CodeComplexity, &a.PerformanceScore, &a.PerformanceGrade); err != nil {
+			return nil, fmt.Errorf("scan analysis: %w", err)
+		}
+		ids = append(ids, id)
+		results = append(results, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, id := range ids {
+		issues, err := s.securityIssuesFor(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		results[i].SecurityIssues = issues
+
+		hints, err := s.performanceHintsFor(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		results[i].PerformanceHints = hints
+	}
+
+	return results, nil
+}
+
+// ScoreTrend implements Store. SQLite has no date_trunc, so the bucket
+// boundary is computed with strftime/date modifiers instead: "day" truncates
+// to midnight, "week" truncates to the most recent Sunday.
+func (s *SQLite) ScoreTrend(ctx context.Context, since time.Time, bucket string) ([]ScorePoint, error) {
+	bucketExpr := "date(analysis_time)"
+	if bucketOrDefault(bucket) == "week" {
+		bucketExpr = "date(analysis_time, '-' || strftime('%w', analysis_time) || ' days')"
+	}
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s AS bucket, AVG(performance_score)
+		FROM analyses
+		WHERE analysis_time >= ?
+		GROUP BY bucket
+		ORDER BY bucket ASC`, bucketExpr), since)
+	if err != nil {
+		return nil, fmt.Errorf("query score trend: %w", err)
+	}
+	defer rows.Close()
+
+	var points []ScorePoint
+	for rows.Next() {
+		var day string
+		var p ScorePoint
+		if err := rows.Scan(&day, &p.AverageScore); err != nil {
+			return nil, fmt.Errorf("scan score point: %w", err)
+		}
+		p.Bucket, err = time.Parse("2006-01-02", day)
+		if err != nil {
+			return nil, fmt.Errorf("parse bucket %q: %w", day, err)
+		}
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
+
+// Count implements Store.
+func (s *SQLite) Count(ctx context.Context) (int, error) {
+	var n int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM analyses`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count analyses: %w", err)
+	}
+	return n, nil
+}
+
+// TopIssues implements Store.
+func (s *SQLite) TopIssues(ctx context.Context, n int) ([]IssueCount, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT kind, name, COUNT(*) AS count FROM (
+			SELECT 'security' AS kind, type AS name FROM security_issues
+			UNION ALL
+			SELECT 'performance' AS kind, issue AS name FROM performance_hints
+		) combined
+		GROUP BY kind, name
+		ORDER BY count DESC
+		LIMIT ?`, n)
+	if err != nil {
+		return nil, fmt.Errorf("query top issues: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []IssueCount
+	for rows.Next() {
+		var c IssueCount
+		if err := rows.Scan(&c.Kind, &c.Name, &c.Count); err != nil {
+			return nil, fmt.Errorf("scan issue count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,127 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+)
+
+func TestSQLite_SaveAndRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "analyzer.db"), PoolConfig{})
+	if err != nil {
+		t.Fatalf("NewSQLite() error = %v", err)
+	}
+	defer s.Close()
+
+	when := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	result := analysis.CodeAnalysis{
+		FilePath:         "handlers/user.go",
+		AnalysisTime:     when,
+		CodeComplexity:   7,
+		PerformanceScore: 82,
+		PerformanceGrade: "B",
+		SecurityIssues: []analysis.SecurityIssue{
+			{Type: "SQL Injection", Description: "tainted query", Severity: "high", LineNumber: 12, Suggestion: "use a parameterized query"},
+		},
+		PerformanceHints: []analysis.PerformanceHint{
+			{Issue: "Potential N+1 Query Pattern", Impact: "slow", Solution: "batch the query", CodeExample: "db.Preload(...)", LineNumber: 20, Severity: "medium"},
+		},
+		AIRecommendations: []analysis.AIRecommendation{
+			{Type: "caching", Confidence: 0.9, Recommendation: "cache this response", AutoFixCode: "// cached"},
+		},
+	}
+
+	if err := s.Save(ctx, result, "abc123"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	n, err := s.Count(ctx)
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("Count() = %d, want 1", n)
+	}
+
+	history, err := s.HistoryForFile(ctx, result.FilePath)
+	if err != nil {
+		t.Fatalf("HistoryForFile() error = %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("HistoryForFile() returned %d analyses, want 1", len(history))
+	}
+	got := history[0]
+	if got.PerformanceScore != result.PerformanceScore || got.PerformanceGrade != result.PerformanceGrade {
+		t.Errorf("HistoryForFile()[0] = %+v, want score/grade %d/%q", got, result.PerformanceScore, result.PerformanceGrade)
+	}
+	if !got.AnalysisTime.Equal(when) {
+		t.Errorf("HistoryForFile()[0].AnalysisTime = %v, want %v", got.AnalysisTime, when)
+	}
+	if len(got.SecurityIssues) != 1 || got.SecurityIssues[0].Type != "SQL Injection" {
+		t.Errorf("HistoryForFile()[0].SecurityIssues = %+v, want one SQL Injection issue", got.SecurityIssues)
+	}
+	if len(got.PerformanceHints) != 1 || got.PerformanceHints[0].Issue != "Potential N+1 Query Pattern" {
+		t.Errorf("HistoryForFile()[0].PerformanceHints = %+v, want one N+1 hint", got.PerformanceHints)
+	}
+
+	byLanguage, err := s.History(ctx, when.Add(-time.Hour), "go")
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(byLanguage) != 1 {
+		t.Fatalf("History() with language=go returned %d analyses, want 1", len(byLanguage))
+	}
+
+	none, err := s.History(ctx, when.Add(-time.Hour), "python")
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(none) != 0 {
+		t.Errorf("History() with language=python returned %d analyses, want 0", len(none))
+	}
+
+	top, err := s.TopIssues(ctx, 10)
+	if err != nil {
+		t.Fatalf("TopIssues() error = %v", err)
+	}
+	if len(top) != 2 {
+		t.Fatalf("TopIssues() returned %d rows, want 2", len(top))
+	}
+}
+
+func TestSQLite_SaveIsIdempotentForSameKey(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "analyzer.db"), PoolConfig{})
+	if err != nil {
+		t.Fatalf("NewSQLite() error = %v", err)
+	}
+	defer s.Close()
+
+	result := analysis.CodeAnalysis{
+		FilePath:         "main.go",
+		AnalysisTime:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		PerformanceScore: 50,
+		PerformanceGrade: "C",
+	}
+
+	if err := s.Save(ctx, result, "sha1"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := s.Save(ctx, result, "sha1"); err != nil {
+		t.Fatalf("second Save() with the same (file_path, analysis_time, git_sha) error = %v", err)
+	}
+
+	n, err := s.Count(ctx)
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Count() = %d, want 1 (ON CONFLICT should have deduped the re-save)", n)
+	}
+}
@@ -0,0 +1,93 @@
+// Package store persists analysis.CodeAnalysis results so callers can track
+// how a codebase's performance/security posture evolves across commits,
+// instead of AnalyzeCode's output being discarded after a single report.
+package store
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+)
+
+// Store is implemented by any persistence backend for analysis history. Open
+// picks between the two built-in implementations: Postgres (see
+// NewPostgres) for a "postgres://"/"postgresql://" DSN, SQLite (see
+// NewSQLite) otherwise.
+type Store interface {
+	// Save persists result under (result.FilePath, result.AnalysisTime,
+	// gitSHA), along with its SecurityIssues, PerformanceHints, and
+	// AIRecommendations. The language column is derived from
+	// result.FilePath's extension via internal/analysis/langs, falling back
+	// to "go" for an unregistered or empty extension.
+	Save(ctx context.Context, result analysis.CodeAnalysis, gitSHA string) error
+
+	// HistoryForFile returns every analysis recorded for path, oldest first.
+	HistoryForFile(ctx context.Context, path string) ([]analysis.CodeAnalysis, error)
+
+	// History returns every analysis recorded since since, oldest first,
+	// across every file. language restricts results to that language
+	// (matching a LanguageAnalyzer.Name(), or "go"); empty means any.
+	History(ctx context.Context, since time.Time, language string) ([]analysis.CodeAnalysis, error)
+
+	// ScoreTrend returns the average PerformanceScore across all files,
+	// bucketed by day or week, for every bucket with at least one analysis
+	// since since. An unrecognized bucket defaults to "day".
+	ScoreTrend(ctx context.Context, since time.Time, bucket string) ([]ScorePoint, error)
+
+	// TopIssues returns the n most frequently recorded issue kinds across
+	// every stored analysis, combining SecurityIssue.Type and
+	// PerformanceHint.Issue, ordered by count descending.
+	TopIssues(ctx context.Context, n int) ([]IssueCount, error)
+
+	// Count returns the total number of analyses ever recorded.
+	Count(ctx context.Context) (int, error)
+
+	// Close releases the underlying connection.
+	Close() error
+}
+
+// ScorePoint is one bucket of ScoreTrend: the average PerformanceScore
+// across all analyses recorded in that bucket.
+type ScorePoint struct {
+	Bucket       time.Time `json:"bucket"`
+	AverageScore float64   `json:"average_score"`
+}
+
+// IssueCount is one row of TopIssues: how often a given issue kind was
+// recorded, and whether it came from SecurityIssues or PerformanceHints.
+type IssueCount struct {
+	Kind  string `json:"kind"` // "security" or "performance"
+	Name  string `json:"name"` // SecurityIssue.Type or PerformanceHint.Issue
+	Count int    `json:"count"`
+}
+
+// PoolConfig tunes the underlying *sql.DB connection pool. Zero values fall
+// back to sql.DB's own defaults (unlimited open conns, no idle timeout).
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// Open opens dsn against the backend its scheme selects: "postgres://" or
+// "postgresql://" opens Postgres, anything else (typically a file path, or
+// "" for the working-directory default) opens the CGO-free SQLite backend.
+// This lets a local run or a CI container without a database get history
+// for free, while production can still point STORE_DSN at Postgres.
+func Open(dsn string, pool PoolConfig) (Store, error) {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		return NewPostgres(dsn, pool)
+	}
+	return NewSQLite(dsn, pool)
+}
+
+// bucketOrDefault normalizes a ScoreTrend bucket argument: anything but
+// "week" is treated as "day".
+func bucketOrDefault(bucket string) string {
+	if bucket == "week" {
+		return "week"
+	}
+	return "day"
+}
@@ -0,0 +1,86 @@
+// Package suppress parses inline suppression comments out of Go source, so
+// a known finding can be silenced at the call site instead of only via the
+// baseline file.
+//
+// Two forms are recognized on (or immediately above) the flagged line:
+//
+//	// nolint:apiperf
+//	db.Raw(query) // nolint:apiperf
+//
+//	// apiperf:ignore sql_injection_risk reason="legacy query, ticket JIRA-123"
+//	db.Raw(query)
+//
+// The bare nolint form silences every rule on that line; the apiperf:ignore
+// form silences only the named rule and records a reason for audit trails.
+// Either form may sit on the flagged line itself or the line directly above
+// it - Matches checks both.
+package suppress
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+)
+
+// Suppression is one parsed inline-suppression comment.
+type Suppression struct {
+	Line   int
+	RuleID string // empty means "suppress every rule on this line"
+	Reason string
+	Used   bool // set by callers once they've matched it to a finding
+}
+
+var (
+	nolintRE = regexp.MustCompile(`^nolint:apiperf\b`)
+	ignoreRE = regexp.MustCompile(`^apiperf:ignore\s+(\S+)(?:\s+reason="([^"]*)")?`)
+)
+
+// Parse scans every comment in file and returns the suppressions found,
+// keyed to the line the comment appears on. A suppression comment that
+// trails code (rather than sitting on its own line) applies to that same
+// line; a standalone comment applies to the line immediately below it.
+func Parse(fset *token.FileSet, file *ast.File) []Suppression {
+	var suppressions []Suppression
+
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			text := stripCommentMarkers(c.Text)
+
+			var ruleID, reason string
+			switch {
+			case nolintRE.MatchString(text):
+				// ruleID stays empty: suppresses every rule on the line.
+			case ignoreRE.MatchString(text):
+				m := ignoreRE.FindStringSubmatch(text)
+				ruleID, reason = m[1], m[2]
+			default:
+				continue
+			}
+
+			suppressions = append(suppressions, Suppression{
+				Line:   fset.Position(c.Pos()).Line,
+				RuleID: ruleID,
+				Reason: reason,
+			})
+		}
+	}
+
+	return suppressions
+}
+
+func stripCommentMarkers(text string) string {
+	if len(text) >= 2 && text[:2] == "//" {
+		text = text[2:]
+	}
+	for len(text) > 0 && text[0] == ' ' {
+		text = text[1:]
+	}
+	return text
+}
+
+// Matches reports whether s silences a finding with the given rule id on
+// the given line, allowing the comment to sit on that line or the one above.
+func (s Suppression) Matches(line int, ruleID string) bool {
+	onLine := s.Line == line || s.Line == line-1
+	return onLine && (s.RuleID == "" || s.RuleID == ruleID)
+}
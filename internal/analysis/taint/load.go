@@ -0,0 +1,39 @@
+package taint
+
+import (
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// BuildSSA type-checks and builds SSA form for every function in pkgs, the
+// shape required to run Analyzer.Run. Synthetic wrappers and functions with
+// no body (pure declarations, cgo stubs) are skipped by the caller.
+func BuildSSA(pkgs []*packages.Package) (*ssa.Program, []*ssa.Package) {
+	prog, ssaPkgs := ssautil.AllPackages(pkgs, ssa.SanityCheckFunctions)
+	prog.Build()
+	return prog, ssaPkgs
+}
+
+// Functions returns the package-level functions defined in ssaPkgs, plus the
+// anonymous functions they directly contain, suitable for iterating
+// Analyzer.Run over. Methods are not included.
+func Functions(ssaPkgs []*ssa.Package) []*ssa.Function {
+	var fns []*ssa.Function
+	for _, pkg := range ssaPkgs {
+		if pkg == nil {
+			continue
+		}
+		for _, member := range pkg.Members {
+			fn, ok := member.(*ssa.Function)
+			if !ok || fn.Blocks == nil {
+				continue
+			}
+			fns = append(fns, fn)
+			for _, anon := range fn.AnonFuncs {
+				fns = append(fns, anon)
+			}
+		}
+	}
+	return fns
+}
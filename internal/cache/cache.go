@@ -0,0 +1,127 @@
+// Package cache provides an on-disk (and pluggable remote) cache for
+// analysis results, keyed by a hash of file content plus the analyzer
+// version and ruleset, so CI runs don't re-analyze unchanged files.
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Store is implemented by any cache backend: the default on-disk FileStore,
+// or a remote backend (S3, GCS, ...) for sharing a cache across CI runners.
+type Store interface {
+	// Get returns the cached bytes for key, or ok=false on a miss.
+	Get(key string) (data []byte, ok bool, err error)
+	// Put stores data under key.
+	Put(key string, data []byte) error
+}
+
+// Key computes the cache key for a file: sha256(content || analyzerVersion
+// || rulesetHash). Changing the analyzer's detection logic or its rule
+// catalog invalidates every cached entry, since either can change the
+// result for unchanged source.
+func Key(content []byte, analyzerVersion, rulesetHash string) string {
+	h := sha256.New()
+	h.Write(content)
+	h.Write([]byte(analyzerVersion))
+	h.Write([]byte(rulesetHash))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// DefaultDir returns $XDG_CACHE_HOME/api-perf-analyzer/v1, falling back to
+// os.UserCacheDir when XDG_CACHE_HOME isn't set.
+func DefaultDir() (string, error) {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "api-perf-analyzer", "v1"), nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "api-perf-analyzer", "v1"), nil
+}
+
+// FileStore is the default Store: one gzipped JSON file per key under Dir.
+type FileStore struct {
+	Dir string
+	// MaxAge discards (and reports as a miss) entries older than MaxAge.
+	// Zero means entries never expire.
+	MaxAge time.Duration
+}
+
+// NewFileStore creates dir if needed and returns a FileStore rooted there.
+func NewFileStore(dir string, maxAge time.Duration) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStore{Dir: dir, MaxAge: maxAge}, nil
+}
+
+func (f *FileStore) path(key string) string {
+	return filepath.Join(f.Dir, key+".json.gz")
+}
+
+func (f *FileStore) Get(key string) ([]byte, bool, error) {
+	path := f.path(key)
+
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if f.MaxAge > 0 && time.Since(info.ModTime()) > f.MaxAge {
+		return nil, false, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, false, err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, false, err
+	}
+	defer gz.Close()
+
+	data, err := io.ReadAll(gz)
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+func (f *FileStore) Put(key string, data []byte) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	tmp := f.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path(key))
+}
+
+// Stats tracks hit/miss counts across a run, for verbose output and GitHub
+// Action outputs.
+type Stats struct {
+	Hits   int
+	Misses int
+}
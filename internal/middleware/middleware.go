@@ -0,0 +1,76 @@
+// Package middleware provides gin middleware guarding /api/v1/analyze
+// against abuse: per-IP rate limiting, a request-size cap, a per-request
+// analysis timeout, and an in-memory response cache for repeat submissions.
+// Every limit is configurable via env vars, and the whole subsystem can be
+// switched off (e.g. in tests) by setting MIDDLEWARE_DISABLED=true.
+package middleware
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config holds the tunables for the abuse-protection middleware.
+type Config struct {
+	// Disabled turns every middleware in this package into a no-op, for
+	// tests that don't want rate limits or timeouts in the way.
+	Disabled bool
+	// RPS and Burst configure the per-IP token bucket.
+	RPS   float64
+	Burst int
+	// MaxBodyBytes rejects request bodies larger than this, before
+	// ShouldBindJSON ever sees them.
+	MaxBodyBytes int64
+	// AnalysisTimeout bounds how long a single analysis may run.
+	AnalysisTimeout time.Duration
+	// CacheSize is the max number of responses ResponseCache keeps.
+	CacheSize int
+	// MaxTrackedIPs is the max number of per-IP limiters RateLimit keeps
+	// alive at once, evicting the least-recently-used IP past this, so a
+	// flood of distinct (e.g. spoofed X-Forwarded-For) IPs can't grow the
+	// limiter set without bound.
+	MaxTrackedIPs int
+}
+
+// DefaultConfig is used when an env var is unset.
+var DefaultConfig = Config{
+	RPS:             5,
+	Burst:           10,
+	MaxBodyBytes:    1 << 20, // 1 MiB
+	AnalysisTimeout: 10 * time.Second,
+	CacheSize:       1000,
+	MaxTrackedIPs:   10000,
+}
+
+// ConfigFromEnv builds a Config from DefaultConfig, overridden by
+// RATE_LIMIT_RPS, RATE_LIMIT_BURST, MAX_BODY_BYTES, ANALYSIS_TIMEOUT
+// (a Go duration string, e.g. "10s"), CACHE_SIZE, MAX_TRACKED_IPS, and
+// MIDDLEWARE_DISABLED.
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig
+
+	if v, err := strconv.ParseFloat(os.Getenv("RATE_LIMIT_RPS"), 64); err == nil {
+		cfg.RPS = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("RATE_LIMIT_BURST")); err == nil {
+		cfg.Burst = v
+	}
+	if v, err := strconv.ParseInt(os.Getenv("MAX_BODY_BYTES"), 10, 64); err == nil {
+		cfg.MaxBodyBytes = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("ANALYSIS_TIMEOUT")); err == nil {
+		cfg.AnalysisTimeout = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("CACHE_SIZE")); err == nil {
+		cfg.CacheSize = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("MAX_TRACKED_IPS")); err == nil {
+		cfg.MaxTrackedIPs = v
+	}
+	if v, err := strconv.ParseBool(os.Getenv("MIDDLEWARE_DISABLED")); err == nil {
+		cfg.Disabled = v
+	}
+
+	return cfg
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// limiterEntry is one IP's token bucket, as stored in the LRU list.
+type limiterEntry struct {
+	ip      string
+	limiter *rate.Limiter
+}
+
+// RateLimit throttles requests per client IP using a token bucket (rps
+// refill rate, burst capacity), returning 429 once a client exceeds it. A
+// disabled Config makes this a no-op, for tests. Tracked IPs are kept in an
+// LRU of at most cfg.MaxTrackedIPs, the same pattern ResponseCache uses, so a
+// flood of distinct (e.g. spoofed X-Forwarded-For) IPs can't grow the
+// limiter set without bound.
+func RateLimit(cfg Config) gin.HandlerFunc {
+	if cfg.Disabled {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	var mu sync.Mutex
+	order := list.New()
+	entries := make(map[string]*list.Element)
+
+	return func(c *gin.Context) {
+		ip := c.ClientIP()
+
+		mu.Lock()
+		elem, ok := entries[ip]
+		if ok {
+			order.MoveToFront(elem)
+		} else {
+			elem = order.PushFront(&limiterEntry{ip: ip, limiter: rate.NewLimiter(rate.Limit(cfg.RPS), cfg.Burst)})
+			entries[ip] = elem
+
+			if cfg.MaxTrackedIPs > 0 && order.Len() > cfg.MaxTrackedIPs {
+				oldest := order.Back()
+				order.Remove(oldest)
+				delete(entries, oldest.Value.(*limiterEntry).ip)
+			}
+		}
+		limiter := elem.Value.(*limiterEntry).limiter
+		mu.Unlock()
+
+		if !limiter.Allow() {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+
+		c.Next()
+	}
+}
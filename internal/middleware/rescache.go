@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// ResponseCache is a fixed-size, in-memory LRU cache of raw response bodies,
+// keyed by CacheKey(code, language). Identical submissions return the
+// cached body instead of re-running analysis.
+type ResponseCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key  string
+	data []byte
+}
+
+// NewResponseCache creates a cache holding at most size entries. size <= 0
+// disables caching: Get always misses and Put is a no-op.
+func NewResponseCache(size int) *ResponseCache {
+	return &ResponseCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// CacheKey is the cache key for a submission: sha256(code+language).
+func CacheKey(code, language string) string {
+	h := sha256.New()
+	h.Write([]byte(code))
+	h.Write([]byte(language))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get returns the cached body for key, promoting it to most-recently-used.
+func (c *ResponseCache) Get(key string) ([]byte, bool) {
+	if c.size <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).data, true
+}
+
+// Put stores data under key, evicting the least-recently-used entry if the
+// cache is full.
+func (c *ResponseCache) Put(key string, data []byte) {
+	if c.size <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*cacheEntry).data = data
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&cacheEntry{key: key, data: data})
+	c.entries[key] = elem
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*cacheEntry).key)
+		}
+	}
+}
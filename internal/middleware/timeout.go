@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalysisTimeout bounds c.Request's context to cfg.AnalysisTimeout, so a
+// handler that threads the request context into AnalyzeCode can't run
+// forever on a pathological submission.
+func AnalysisTimeout(cfg Config) gin.HandlerFunc {
+	if cfg.Disabled {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), cfg.AnalysisTimeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
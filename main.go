@@ -1,12 +1,18 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"time"
 
-	"github.com/your-username/api-performance-analyzer/internal/analysis"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/batch"
+	"github.com/aldookware/api-performance-analyzer/internal/analysis/langs"
+	"github.com/aldookware/api-performance-analyzer/internal/middleware"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -17,6 +23,12 @@ type AnalyzeRequest struct {
 	Type string `json:"type"`
 }
 
+// respCache caches /api/v1/analyze responses keyed by sha256(code+type), so
+// identical submissions skip re-running analysis. Set up in main(); nil
+// (and therefore a guaranteed miss) in contexts that construct handlers
+// without it, such as tests.
+var respCache *middleware.ResponseCache
+
 // analyzeCode analyzes Go REST API code for patterns and issues
 func analyzeCode(c *gin.Context) {
 	var request AnalyzeRequest
@@ -31,9 +43,205 @@ func analyzeCode(c *gin.Context) {
 		return
 	}
 
-	// Use the new analysis package
-	result := analysis.AnalyzeCode(request.Code, request.Type, "input.go")
-	c.JSON(http.StatusOK, result)
+	format := outputFormat(c)
+
+	// Only the default JSON representation is cached: sarif/junit are
+	// requested rarely enough (CI jobs, not the dashboard) that caching
+	// them too would mean keying the cache by format as well.
+	key := middleware.CacheKey(request.Code, request.Type)
+	if format == "json" && respCache != nil {
+		if cached, ok := respCache.Get(key); ok {
+			c.Header("X-Cache", "HIT")
+			c.Data(http.StatusOK, "application/json; charset=utf-8", cached)
+			return
+		}
+	}
+
+	result, err := analyzeByType(c.Request.Context(), request.Code, request.Type)
+	if err != nil {
+		c.JSON(http.StatusGatewayTimeout, gin.H{"error": "analysis did not finish in time: " + err.Error()})
+		return
+	}
+	recordHistory(result)
+
+	var data []byte
+	var contentType string
+	switch format {
+	case "sarif":
+		contentType = "application/sarif+json; charset=utf-8"
+		data, err = result.ToSARIF()
+	case "junit":
+		contentType = "application/xml; charset=utf-8"
+		data, err = result.ToJUnit()
+	default:
+		contentType = "application/json; charset=utf-8"
+		data, err = json.Marshal(result)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to encode result: " + err.Error()})
+		return
+	}
+	if format == "json" && respCache != nil {
+		respCache.Put(key, data)
+	}
+
+	c.Header("X-Cache", "MISS")
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// outputFormat picks analyzeCode's response representation from the
+// ?format= query param, falling back to the Accept header, so a CI job can
+// request SARIF either way: ?format=sarif or Accept: application/sarif+json.
+// Anything unrecognized (including a plain "Accept: */*") defaults to JSON.
+func outputFormat(c *gin.Context) string {
+	switch strings.ToLower(c.Query("format")) {
+	case "sarif":
+		return "sarif"
+	case "junit", "xml":
+		return "junit"
+	}
+
+	accept := c.GetHeader("Accept")
+	switch {
+	case strings.Contains(accept, "application/sarif+json"):
+		return "sarif"
+	case strings.Contains(accept, "application/xml"):
+		return "junit"
+	default:
+		return "json"
+	}
+}
+
+// analyzeByType dispatches to the LanguageAnalyzer registered under
+// codeType (see internal/analysis/langs), so requests tagged "python",
+// "javascript", or "java" actually run that language's checks instead of
+// always being parsed as Go. Requests with an unregistered or empty type
+// (including the original "gin"/"echo" framework tags) fall back to the
+// native Go analyzer, preserving this endpoint's original behavior. ctx is
+// honored all the way down (see analysis.AnalyzeCodeContext), so a caller
+// enforcing a per-request deadline (see internal/middleware.AnalysisTimeout)
+// actually bounds the Go analysis path too, not just the registry one.
+//
+// This dispatch deliberately lives here rather than as a method on
+// analysis.AnalyzeCode: LanguageAnalyzer.Analyze (internal/analysis/langs)
+// returns an analysis.CodeAnalysis, so internal/analysis/langs already
+// imports internal/analysis — the reverse import would be a cycle. Package
+// main is the first caller both packages can see, so it's where the two
+// meet.
+func analyzeByType(ctx context.Context, code, codeType string) (analysis.CodeAnalysis, error) {
+	lang, ok := langs.ForName(codeType)
+	if !ok {
+		return analysis.AnalyzeCodeContext(ctx, code, codeType, "input.go")
+	}
+
+	result, _ := lang.Analyze(ctx, "input"+lang.Extensions()[0], []byte(code))
+	if err := ctx.Err(); err != nil {
+		return analysis.CodeAnalysis{}, fmt.Errorf("analysis did not complete: %w", err)
+	}
+	return result, nil
+}
+
+// analyzeCodeStream handles POST /api/v1/analyze/stream: it runs the same
+// analysis as analyzeCode but pushes each finding over Server-Sent Events as
+// soon as it's produced, plus a final "summary" event, so the dashboard can
+// render results incrementally instead of waiting for large files to finish.
+func analyzeCodeStream(c *gin.Context) {
+	var request AnalyzeRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	if strings.TrimSpace(request.Code) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Code cannot be empty"})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	ctx := c.Request.Context()
+	events := make(chan analysis.AnalysisEvent)
+	go streamByType(ctx, request.Code, request.Type, events)
+
+	keepAlive := time.NewTicker(15 * time.Second)
+	defer keepAlive.Stop()
+
+	eventID := 0
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			return true
+		case evt, ok := <-events:
+			if !ok {
+				return false
+			}
+			eventID++
+			data, _ := json.Marshal(evt)
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", eventID, evt.Type, data)
+			return true
+		}
+	})
+}
+
+// streamByType dispatches to the LanguageAnalyzer registered under codeType,
+// synthesizing incremental events from its result since LanguageAnalyzer has
+// no streaming API of its own. Go (an unregistered or empty codeType) gets
+// true incremental events straight from analysis.AnalyzeCodeStreaming.
+func streamByType(ctx context.Context, code, codeType string, events chan<- analysis.AnalysisEvent) {
+	lang, ok := langs.ForName(codeType)
+	if !ok {
+		analysis.AnalyzeCodeStreaming(ctx, code, codeType, "input.go", events)
+		return
+	}
+
+	defer close(events)
+
+	result, err := lang.Analyze(ctx, "input"+lang.Extensions()[0], []byte(code))
+	if err != nil {
+		return
+	}
+	for _, issue := range result.SecurityIssues {
+		issue := issue
+		if !sendAnalysisEvent(ctx, events, analysis.AnalysisEvent{Type: analysis.EventTypeSecurityIssue, SecurityIssue: &issue}) {
+			return
+		}
+	}
+	for _, hint := range result.PerformanceHints {
+		hint := hint
+		if !sendAnalysisEvent(ctx, events, analysis.AnalysisEvent{Type: analysis.EventTypePerformanceHint, PerformanceHint: &hint}) {
+			return
+		}
+	}
+	for _, practice := range result.BestPractices {
+		practice := practice
+		if !sendAnalysisEvent(ctx, events, analysis.AnalysisEvent{Type: analysis.EventTypeBestPractice, BestPractice: &practice}) {
+			return
+		}
+	}
+	for _, rec := range result.AIRecommendations {
+		rec := rec
+		if !sendAnalysisEvent(ctx, events, analysis.AnalysisEvent{Type: analysis.EventTypeAIRecommendation, AIRecommendation: &rec}) {
+			return
+		}
+	}
+	sendAnalysisEvent(ctx, events, analysis.AnalysisEvent{Type: analysis.EventTypeSummary, Summary: &result})
+}
+
+// sendAnalysisEvent delivers evt on events, reporting false instead of
+// blocking forever if ctx is cancelled first (e.g. the client disconnected).
+func sendAnalysisEvent(ctx context.Context, events chan<- analysis.AnalysisEvent, evt analysis.AnalysisEvent) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case events <- evt:
+		return true
+	}
 }
 
 // getHealth returns API health status
@@ -48,9 +256,16 @@ func getHealth(c *gin.Context) {
 
 // getStats returns usage statistics
 func getStats(c *gin.Context) {
+	totalAnalyses := 0
+	if historyStore != nil {
+		if n, err := historyStore.Count(c.Request.Context()); err == nil {
+			totalAnalyses = n
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"total_analyses":      0, // In production, track this in database
-		"supported_languages": []string{"go", "gin", "echo"},
+		"total_analyses":      totalAnalyses,
+		"supported_languages": langs.Names(),
 		"features": []string{
 			"N+1 Query Detection",
 			"Missing Index Analysis",
@@ -80,6 +295,14 @@ func main() {
 	// Add recovery middleware
 	router.Use(gin.Recovery())
 
+	mwConfig := middleware.ConfigFromEnv()
+	respCache = middleware.NewResponseCache(mwConfig.CacheSize)
+	batchManager = batch.NewManager()
+	historyStore = openHistoryStore()
+	if historyStore != nil {
+		defer historyStore.Close()
+	}
+
 	// Serve static files (for the web interface)
 	router.Static("/static", "./static")
 	router.GET("/", func(c *gin.Context) {
@@ -88,10 +311,23 @@ func main() {
 
 	// API routes
 	api := router.Group("/api/v1")
+	api.Use(middleware.RateLimit(mwConfig), middleware.MaxBodySize(mwConfig))
 	{
 		api.GET("/health", getHealth)
 		api.GET("/stats", getStats)
-		api.POST("/analyze", analyzeCode)
+		// AnalysisTimeout only applies here: it bounds c.Request's context,
+		// which analyzeCode threads straight into AnalyzeCodeContext. The
+		// other handlers below either stream for longer than that timeout
+		// on purpose (analyzeCodeStream) or already detach their work onto
+		// context.Background() (analyzeBatch) and would be killed by a
+		// group-wide timeout for no benefit.
+		api.POST("/analyze", middleware.AnalysisTimeout(mwConfig), analyzeCode)
+		api.POST("/analyze/stream", analyzeCodeStream)
+		api.POST("/analyze/batch", analyzeBatch)
+		api.GET("/analyze/batch/:jobID/status", batchStatus)
+		api.GET("/history", getHistory)
+		api.GET("/trends", getTrends)
+		api.GET("/rules/top", getTopRules)
 	}
 
 	fmt.Println("🚀 API Performance Analyzer starting...")